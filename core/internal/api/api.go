@@ -203,14 +203,28 @@ type ClientOptions struct {
 	// If Proxy returns a non-nil error, the request is aborted with the error.
 	//
 	// If Proxy is nil or returns a nil *URL, no proxy will be used.
+	//
+	// Ignored if Transport is set.
 	Proxy func(*http.Request) (*url.URL, error)
 
 	// Whether to disable SSL certificate verification.
 	//
 	// This is insecure and should only be used for testing/debugging
 	// or in environments where the backend is trusted.
+	//
+	// Ignored if Transport is set.
 	InsecureDisableSSL bool
 
+	// The transport to use for sending HTTP requests.
+	//
+	// This allows configuring TLS (such as client certificates and root CAs),
+	// connection timeouts and proxies directly. The client still applies its
+	// own retries, rate-limiting and auth headers on top of it.
+	//
+	// If nil, a transport is created using Proxy, InsecureDisableSSL
+	// and the Proxy-Authorization extra header.
+	Transport http.RoundTripper
+
 	// Adds credentials to http requests.
 	CredentialProvider CredentialProvider
 
@@ -266,6 +280,28 @@ func (backend *Backend) NewClient(opts ClientOptions) Client {
 		return backend.credentialProvider.Apply(req)
 	}
 
+	transport := opts.Transport
+	if transport == nil {
+		transport = newDefaultTransport(opts)
+	}
+
+	retryableHTTP.HTTPClient.Transport =
+		NewPeekingTransport(
+			opts.NetworkPeeker,
+			NewRateLimitedTransport(transport),
+		)
+
+	return &clientImpl{
+		backend:            backend,
+		retryableHTTP:      retryableHTTP,
+		extraHeaders:       opts.ExtraHeaders,
+		credentialProvider: opts.CredentialProvider,
+	}
+}
+
+// newDefaultTransport creates the transport to use if the client options
+// don't specify one.
+func newDefaultTransport(opts ClientOptions) *http.Transport {
 	// Set the Proxy function on the HTTP client.
 	transport := &http.Transport{
 		Proxy: opts.Proxy,
@@ -287,16 +323,5 @@ func (backend *Backend) NewClient(opts ClientOptions) Client {
 		}
 	}
 
-	retryableHTTP.HTTPClient.Transport =
-		NewPeekingTransport(
-			opts.NetworkPeeker,
-			NewRateLimitedTransport(transport),
-		)
-
-	return &clientImpl{
-		backend:            backend,
-		retryableHTTP:      retryableHTTP,
-		extraHeaders:       opts.ExtraHeaders,
-		credentialProvider: opts.CredentialProvider,
-	}
+	return transport
 }
@@ -237,3 +237,72 @@ func TestNewClientWithRetry(t *testing.T) {
 	assert.Equal(t, 1, retryCallCount)
 	assert.Equal(t, 2, serverCallCount)
 }
+
+func TestNewClientWithTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("OK"))
+		}),
+	)
+	defer server.Close()
+	settings := wbsettings.From(&spb.Settings{
+		BaseUrl: &wrapperspb.StringValue{Value: server.URL + "/wandb"},
+		ApiKey:  &wrapperspb.StringValue{Value: "test_api_key"},
+	})
+
+	// The server's client trusts the server's self-signed certificate.
+	transport := &countingTransport{delegate: server.Client().Transport}
+	resp, err := newClient(t, settings, api.ClientOptions{
+		Transport: transport,
+	}).Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, transport.requests, 1)
+	assert.Equal(t, "Basic YXBpOnRlc3RfYXBpX2tleQ==",
+		transport.requests[0].Header.Get("Authorization"))
+}
+
+func TestNewClientWithTransport_Retries(t *testing.T) {
+	serverCallCount := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			serverCallCount++
+			if serverCallCount == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte("OK"))
+		}),
+	)
+	defer server.Close()
+	settings := wbsettings.From(&spb.Settings{
+		BaseUrl: &wrapperspb.StringValue{Value: server.URL + "/wandb"},
+		ApiKey:  &wrapperspb.StringValue{Value: "test_api_key"},
+	})
+
+	transport := &countingTransport{delegate: http.DefaultTransport}
+	resp, err := newClient(t, settings, api.ClientOptions{
+		Transport:    transport,
+		RetryMax:     2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	}).Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, transport.requests, 2)
+}
+
+// countingTransport records requests before passing them on to a delegate.
+type countingTransport struct {
+	delegate http.RoundTripper
+	requests []*http.Request
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return t.delegate.RoundTrip(req)
+}
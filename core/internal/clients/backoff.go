@@ -10,9 +10,9 @@ import (
 
 // ExponentialBackoffWithJitter returns a duration to sleep for based on the
 // attempt number, the minimum and maximum durations, and the response.
-// If the response is nil or not a 429, the response is ignored.
-// If the response is a 429, the Retry-After header is used to determine the
-// duration to sleep for.
+// If the response is nil or not a 429 or 503, the response is ignored.
+// If the response is a 429 or 503, the Retry-After header is used to
+// determine the duration to sleep for.
 // Otherwise, the sleep duration is calculated as:
 //
 //	min * 2^(attemptNum)
@@ -29,12 +29,11 @@ func ExponentialBackoffWithJitter(min, max time.Duration, attemptNum int, resp *
 	}
 
 	if resp != nil {
-		if resp.StatusCode == http.StatusTooManyRequests {
-			if s, ok := resp.Header["Retry-After"]; ok {
-				if sleep, err := strconv.ParseFloat(s[0], 64); err == nil {
-					// Add jitter in case of 429 status code
-					return addJitter(SecondsToDuration(sleep))
-				}
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			if sleep, ok := parseRetryAfter(resp); ok {
+				// Add jitter in case of 429 or 503 status code
+				return addJitter(sleep)
 			}
 		}
 	}
@@ -50,3 +49,24 @@ func ExponentialBackoffWithJitter(min, max time.Duration, attemptNum int, resp *
 	}
 	return sleep
 }
+
+// parseRetryAfter returns the duration given by the Retry-After header.
+//
+// The header may be a number of seconds or an HTTP date. The second
+// return value is false if the header is missing or invalid.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return SecondsToDuration(seconds), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
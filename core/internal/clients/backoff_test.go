@@ -59,3 +59,33 @@ func TestExponentialBackoffWithJitter_MaxBackoffLimit(t *testing.T) {
 
 	assert.LessOrEqual(t, backoff, max, "Backoff should not exceed max limit")
 }
+
+func TestExponentialBackoffWithJitter_HTTP503(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     make(http.Header),
+	}
+	resp.Header.Set("Retry-After", "5")
+
+	backoff := clients.ExponentialBackoffWithJitter(
+		time.Second, 10*time.Second, 1, resp)
+
+	assert.GreaterOrEqual(t, backoff, 5*time.Second)
+	assert.LessOrEqual(t, backoff, 5*time.Second+5*time.Second/4)
+}
+
+func TestExponentialBackoffWithJitter_RetryAfterHTTPDate(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     make(http.Header),
+	}
+	resp.Header.Set("Retry-After",
+		time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+
+	backoff := clients.ExponentialBackoffWithJitter(
+		time.Second, 10*time.Second, 1, resp)
+
+	// HTTP dates have a resolution of one second.
+	assert.GreaterOrEqual(t, backoff, 58*time.Second)
+	assert.LessOrEqual(t, backoff, 76*time.Second)
+}
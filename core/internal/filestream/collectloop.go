@@ -1,9 +1,9 @@
 package filestream
 
 import (
-	"time"
-
 	"golang.org/x/time/rate"
+
+	"github.com/wandb/wandb/core/internal/waiting"
 )
 
 // CollectLoop batches changes together to make filestream requests.
@@ -13,6 +13,20 @@ import (
 type CollectLoop struct {
 	TransmitRateLimit   *rate.Limiter
 	MaxRequestSizeBytes int
+
+	// MaxHistoryBatchSize is the number of history lines after which
+	// to transmit regardless of the rate limit.
+	//
+	// If zero or negative, history lines do not bypass the rate limit.
+	MaxHistoryBatchSize int
+
+	// Clock is used to wait for the rate limit.
+	//
+	// If nil, the real clock is used.
+	Clock waiting.Clock
+
+	// stats, if set, is updated with the size of the buffered data.
+	stats *statsTracker
 }
 
 // Start ingests requests and outputs rate-limited, batched requests.
@@ -27,15 +41,18 @@ func (cl CollectLoop) Start(
 
 		for request := range requests {
 			buffer.Merge(request)
+			cl.recordBuffer(buffer)
 
 			cl.waitForRateLimit(buffer, requests)
 			buffer, isDone = cl.transmit(buffer, requests, transmissions)
+			cl.recordBuffer(buffer)
 		}
 
 		for !isDone {
 			reader, _ := NewRequestReader(buffer, cl.MaxRequestSizeBytes)
 			transmissions <- reader
 			buffer, isDone = reader.Next()
+			cl.recordBuffer(buffer)
 		}
 
 		close(transmissions)
@@ -54,7 +71,12 @@ func (cl CollectLoop) waitForRateLimit(
 		return
 	}
 
-	reservation := cl.TransmitRateLimit.Reserve()
+	clock := cl.Clock
+	if clock == nil {
+		clock = waiting.NewRealClock()
+	}
+
+	reservation := cl.TransmitRateLimit.ReserveN(clock.Now(), 1)
 
 	// If we would be rate-limited forever, just ignore the limit.
 	if !reservation.OK() {
@@ -62,9 +84,9 @@ func (cl CollectLoop) waitForRateLimit(
 	}
 
 	for {
-		timer := time.NewTimer(reservation.Delay())
+		timer := clock.NewTimer(reservation.DelayFrom(clock.Now()))
 		select {
-		case <-timer.C:
+		case <-timer.C():
 			return
 
 		case request, ok := <-requests:
@@ -75,6 +97,7 @@ func (cl CollectLoop) waitForRateLimit(
 			}
 
 			buffer.Merge(request)
+			cl.recordBuffer(buffer)
 
 			if cl.shouldSendASAP(buffer) {
 				return
@@ -102,10 +125,18 @@ func (cl CollectLoop) transmit(
 			}
 
 			buffer.Merge(request)
+			cl.recordBuffer(buffer)
 		}
 	}
 }
 
+// recordBuffer updates stats about the buffered data, if enabled.
+func (cl CollectLoop) recordBuffer(buffer *FileStreamRequest) {
+	if cl.stats != nil {
+		cl.stats.SetBuffer(buffer)
+	}
+}
+
 // shouldSendASAP returns a request should be made regardless of rate limits.
 func (cl CollectLoop) shouldSendASAP(request *FileStreamRequest) bool {
 	_, isTruncated := NewRequestReader(request, cl.MaxRequestSizeBytes)
@@ -123,6 +154,11 @@ func (cl CollectLoop) shouldSendASAP(request *FileStreamRequest) bool {
 	case request.Preempting:
 		return true
 
+	// Send a full batch of history immediately.
+	case cl.MaxHistoryBatchSize > 0 &&
+		len(request.HistoryLines) >= cl.MaxHistoryBatchSize:
+		return true
+
 	default:
 		return false
 	}
@@ -49,3 +49,30 @@ func TestCollectLoop_SendsLastRequestImmediately(t *testing.T) {
 		t.Error("timeout after 1 second")
 	}
 }
+
+func TestCollectLoop_SendsFullHistoryBatchImmediately(t *testing.T) {
+	requests := make(chan *FileStreamRequest)
+	defer close(requests)
+	// Use a rate limiter that won't let requests through for a long time.
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	limiter.Allow()
+	loop := CollectLoop{
+		TransmitRateLimit:   limiter,
+		MaxRequestSizeBytes: 1 << 20,
+		MaxHistoryBatchSize: 2,
+	}
+
+	transmissions := loop.Start(requests)
+	requests <- &FileStreamRequest{HistoryLines: []string{"1"}}
+	requests <- &FileStreamRequest{HistoryLines: []string{"2"}}
+
+	select {
+	case result := <-transmissions:
+		req := result.GetJSON(&FileStreamState{})
+		assert.Equal(t,
+			[]string{"1", "2"},
+			req.Files[HistoryFileName].Content)
+	case <-time.After(time.Second):
+		t.Error("timeout after 1 second")
+	}
+}
@@ -0,0 +1,33 @@
+package filestream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"sync"
+)
+
+// gzipWriterPool reuses gzip writers across requests.
+//
+// Allocating a gzip.Writer is relatively expensive, and the filestream
+// may make a request every few seconds for the lifetime of a run.
+var gzipWriterPool = sync.Pool{
+	New: func() any { return gzip.NewWriter(nil) },
+}
+
+// gzipCompress returns the gzip-compressed data.
+func gzipCompress(data []byte) ([]byte, error) {
+	writer := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(writer)
+
+	var buf bytes.Buffer
+	writer.Reset(&buf)
+
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -74,6 +74,11 @@ type FileStream interface {
 
 	// StreamUpdate uploads information through the filestream API.
 	StreamUpdate(update Update)
+
+	// Stats returns information about data waiting to be uploaded.
+	//
+	// It is safe to call concurrently with other methods.
+	Stats() Stats
 }
 
 // fileStream is a stream of data to the server
@@ -104,10 +109,26 @@ type fileStream struct {
 	// The rate limit for sending data to the backend.
 	transmitRateLimit *rate.Limiter
 
+	// The number of history lines that triggers a request
+	// regardless of the rate limit, if positive.
+	maxHistoryBatchSize int
+
+	// Whether to gzip request bodies.
+	enableCompression bool
+
+	// How long to retry a request before giving up, if positive.
+	retryMaxElapsedTime time.Duration
+
 	// A schedule on which to send heartbeats to the backend
 	// to prove the run is still alive.
 	heartbeatStopwatch waiting.Stopwatch
 
+	// Statistics about the upload backlog.
+	stats *statsTracker
+
+	// The source of the current time and of timers.
+	clock waiting.Clock
+
 	// A channel that is closed if there is a fatal error.
 	deadChan     chan struct{}
 	deadChanOnce *sync.Once
@@ -121,6 +142,37 @@ type FileStreamParams struct {
 	ApiClient          api.Client
 	TransmitRateLimit  *rate.Limiter
 	HeartbeatStopwatch waiting.Stopwatch
+
+	// FlushInterval is the minimum time between requests.
+	//
+	// Updates made during this time are coalesced into a single request.
+	// It is ignored if TransmitRateLimit is set. If zero, a default
+	// is used.
+	FlushInterval time.Duration
+
+	// MaxBatchSize is the number of history lines that, once buffered,
+	// are sent without waiting for the FlushInterval.
+	//
+	// If zero, history is only sent on the FlushInterval.
+	MaxBatchSize int
+
+	// EnableCompression makes requests use gzip encoding.
+	//
+	// This should only be set if the server supports
+	// "Content-Encoding: gzip" on filestream requests.
+	EnableCompression bool
+
+	// RetryMaxElapsedTime is how long to keep retrying a request.
+	//
+	// If a request doesn't succeed within this time, the filestream
+	// stops with a fatal error. If zero, requests are retried until
+	// the API client's retry limit is reached.
+	RetryMaxElapsedTime time.Duration
+
+	// Clock is used for rate limiting and flush timestamps.
+	//
+	// If nil, the real clock is used.
+	Clock waiting.Clock
 }
 
 func NewFileStream(params FileStreamParams) FileStream {
@@ -142,6 +194,7 @@ func NewFileStream(params FileStreamParams) FileStream {
 		feedbackWait: &sync.WaitGroup{},
 		deadChanOnce: &sync.Once{},
 		deadChan:     make(chan struct{}),
+		stats:        &statsTracker{},
 	}
 
 	fs.heartbeatStopwatch = params.HeartbeatStopwatch
@@ -151,7 +204,21 @@ func NewFileStream(params FileStreamParams) FileStream {
 
 	fs.transmitRateLimit = params.TransmitRateLimit
 	if fs.transmitRateLimit == nil {
-		fs.transmitRateLimit = rate.NewLimiter(rate.Every(defaultTransmitInterval), 1)
+		flushInterval := params.FlushInterval
+		if flushInterval <= 0 {
+			flushInterval = defaultTransmitInterval
+		}
+
+		fs.transmitRateLimit = rate.NewLimiter(rate.Every(flushInterval), 1)
+	}
+
+	fs.maxHistoryBatchSize = params.MaxBatchSize
+	fs.enableCompression = params.EnableCompression
+	fs.retryMaxElapsedTime = params.RetryMaxElapsedTime
+
+	fs.clock = params.Clock
+	if fs.clock == nil {
+		fs.clock = waiting.NewRealClock()
 	}
 
 	return fs
@@ -179,13 +246,19 @@ func (fs *fileStream) Start(
 
 func (fs *fileStream) StreamUpdate(update Update) {
 	fs.logger.Debug("filestream: stream update", "update", update)
+	fs.stats.queuedUpdates.Add(1)
 	select {
 	case fs.processChan <- update:
 	case <-fs.deadChan:
 		// Ignore everything if the filestream is dead.
+		fs.stats.queuedUpdates.Add(-1)
 	}
 }
 
+func (fs *fileStream) Stats() Stats {
+	return fs.stats.Snapshot()
+}
+
 func (fs *fileStream) FinishWithExit(exitCode int32) {
 	fs.StreamUpdate(&ExitUpdate{ExitCode: exitCode})
 	fs.FinishWithoutExit()
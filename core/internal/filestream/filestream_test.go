@@ -0,0 +1,177 @@
+package filestream_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/internal/apitest"
+	"github.com/wandb/wandb/core/internal/clients"
+	. "github.com/wandb/wandb/core/internal/filestream"
+	"github.com/wandb/wandb/core/internal/observability"
+	"github.com/wandb/wandb/core/internal/settings"
+	spb "github.com/wandb/wandb/core/pkg/service_go_proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newTestFileStream returns a FileStream that uploads to a test server.
+func newTestFileStream(
+	t *testing.T,
+	server *apitest.RecordingServer,
+	params FileStreamParams,
+	clientOpts api.ClientOptions,
+) FileStream {
+	t.Helper()
+
+	s := settings.From(&spb.Settings{
+		BaseUrl: wrapperspb.String(server.URL),
+		ApiKey:  wrapperspb.String("test_api_key"),
+	})
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	credentialProvider, err := api.NewCredentialProvider(
+		s,
+		observability.NewNoOpLogger().Logger,
+	)
+	require.NoError(t, err)
+
+	params.Settings = s
+	params.Logger = observability.NewNoOpLogger()
+	if params.Printer == nil {
+		params.Printer = observability.NewPrinter()
+	}
+	params.ApiClient = api.New(api.BackendOptions{
+		BaseURL:            baseURL,
+		CredentialProvider: credentialProvider,
+	}).NewClient(clientOpts)
+
+	return NewFileStream(params)
+}
+
+func respondWithEmptyJSON(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte("{}"))
+}
+
+func TestFileStream_Compression_RoundTrip(t *testing.T) {
+	server := apitest.NewRecordingServer(
+		apitest.WithHandlerFunc(respondWithEmptyJSON))
+	defer server.Close()
+	fs := newTestFileStream(t, server,
+		FileStreamParams{EnableCompression: true},
+		api.ClientOptions{})
+
+	fs.Start("entity", "project", "run", nil)
+	fs.FinishWithExit(3)
+
+	requests := server.Requests()
+	require.NotEmpty(t, requests)
+	lastRequest := requests[len(requests)-1]
+	assert.Equal(t, "gzip", lastRequest.Header.Get("Content-Encoding"))
+	reader, err := gzip.NewReader(bytes.NewReader(lastRequest.Body))
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	var data FileStreamRequestJSON
+	require.NoError(t, json.Unmarshal(body, &data))
+	require.NotNil(t, data.Complete)
+	require.NotNil(t, data.ExitCode)
+	assert.True(t, *data.Complete)
+	assert.EqualValues(t, 3, *data.ExitCode)
+}
+
+func TestFileStream_NoCompressionByDefault(t *testing.T) {
+	server := apitest.NewRecordingServer(
+		apitest.WithHandlerFunc(respondWithEmptyJSON))
+	defer server.Close()
+	fs := newTestFileStream(t, server,
+		FileStreamParams{},
+		api.ClientOptions{})
+
+	fs.Start("entity", "project", "run", nil)
+	fs.FinishWithExit(0)
+
+	requests := server.Requests()
+	require.NotEmpty(t, requests)
+	lastRequest := requests[len(requests)-1]
+	assert.Empty(t, lastRequest.Header.Get("Content-Encoding"))
+	assert.True(t, json.Valid(lastRequest.Body))
+}
+
+func TestFileStream_RetryMaxElapsedTime_StopsRetrying(t *testing.T) {
+	server := apitest.NewRecordingServer(
+		apitest.WithHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+	defer server.Close()
+	printer := observability.NewPrinter()
+	fs := newTestFileStream(t, server,
+		FileStreamParams{
+			Printer:             printer,
+			RetryMaxElapsedTime: 50 * time.Millisecond,
+		},
+		api.ClientOptions{
+			RetryPolicy:  clients.RetryMostFailures,
+			RetryMax:     1000,
+			RetryWaitMin: time.Millisecond,
+			RetryWaitMax: time.Millisecond,
+		})
+
+	fs.Start("entity", "project", "run", nil)
+	fs.FinishWithExit(0)
+
+	requests := server.Requests()
+	require.Greater(t, len(requests), 1)
+	for _, request := range requests[1:] {
+		assert.Equal(t, requests[0].Body, request.Body)
+	}
+	assert.Len(t, printer.Read(), 1)
+}
+
+func TestFileStream_Stats(t *testing.T) {
+	received := make(chan struct{}, 10)
+	release := make(chan struct{})
+	server := apitest.NewRecordingServer(
+		apitest.WithHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received <- struct{}{}
+			<-release
+			respondWithEmptyJSON(w, r)
+		}))
+	defer server.Close()
+	fs := newTestFileStream(t, server,
+		FileStreamParams{},
+		api.ClientOptions{})
+	historyUpdate := func() *HistoryUpdate {
+		return &HistoryUpdate{Record: &spb.HistoryRecord{
+			Item: []*spb.HistoryItem{{Key: "x", ValueJson: "1"}},
+		}}
+	}
+
+	fs.Start("entity", "project", "run", nil)
+	fs.StreamUpdate(historyUpdate()) // sent immediately, blocks on server
+	<-received
+	fs.StreamUpdate(historyUpdate())
+	fs.StreamUpdate(historyUpdate())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.EqualValues(c, 2, fs.Stats().BufferedLines)
+	}, time.Second, time.Millisecond)
+	statsWhileBlocked := fs.Stats()
+	close(release)
+	fs.FinishWithExit(0)
+	statsAfterFinish := fs.Stats()
+
+	assert.Zero(t, statsWhileBlocked.QueuedUpdates)
+	assert.Positive(t, statsWhileBlocked.BufferedBytes)
+	assert.True(t, statsWhileBlocked.LastSuccessfulFlush.IsZero())
+	assert.Zero(t, statsAfterFinish.QueuedUpdates)
+	assert.Zero(t, statsAfterFinish.BufferedLines)
+	assert.Zero(t, statsAfterFinish.BufferedBytes)
+	assert.False(t, statsAfterFinish.LastSuccessfulFlush.IsZero())
+}
@@ -3,6 +3,7 @@ package filestream
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,6 +28,7 @@ func (fs *fileStream) startProcessingUpdates(
 		fs.logger.Debug("filestream: open", "path", fs.path)
 
 		for update := range updates {
+			fs.stats.queuedUpdates.Add(-1)
 			err := update.Apply(UpdateContext{
 				MakeRequest: func(req *FileStreamRequest) {
 					requests <- req
@@ -46,6 +48,7 @@ func (fs *fileStream) startProcessingUpdates(
 
 		// Flush input channel if we exited early.
 		for range updates {
+			fs.stats.queuedUpdates.Add(-1)
 		}
 	}()
 
@@ -72,6 +75,9 @@ func (fs *fileStream) startTransmitting(
 	transmissions := CollectLoop{
 		TransmitRateLimit:   fs.transmitRateLimit,
 		MaxRequestSizeBytes: int(maxRequestSizeBytes),
+		MaxHistoryBatchSize: fs.maxHistoryBatchSize,
+		Clock:               fs.clock,
+		stats:               fs.stats,
 	}.Start(requests)
 
 	feedback := TransmitLoop{
@@ -124,13 +130,38 @@ func (fs *fileStream) send(
 		},
 	}
 
+	if fs.enableCompression {
+		compressedData, err := gzipCompress(jsonData)
+		if err != nil {
+			return fmt.Errorf("filestream: gzip error in send(): %v", err)
+		}
+
+		req.Body = compressedData
+		req.Headers["Content-Encoding"] = "gzip"
+	}
+
 	op := fs.trackUploadOperation(data)
 	defer op.Finish()
-	req.Context = op.Context(context.Background())
+	ctx := op.Context(context.Background())
+
+	// The same request is retried on failure, so the server never sees
+	// a batch of lines twice with different offsets.
+	if fs.retryMaxElapsedTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, fs.retryMaxElapsedTime)
+		defer cancel()
+	}
+	req.Context = ctx
 
 	resp, err := fs.apiClient.Send(req)
 
 	switch {
+	case err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf(
+			"filestream: gave up after retrying for %v: %v",
+			fs.retryMaxElapsedTime,
+			err,
+		)
 	case err != nil:
 		return fmt.Errorf(
 			"filestream: error making HTTP request: %v. got response: %v",
@@ -167,6 +198,7 @@ func (fs *fileStream) send(
 		fs.logger.CaptureError(
 			fmt.Errorf("filestream: json decode error: %v", err))
 	}
+	fs.stats.RecordSuccessfulFlush(fs.clock.Now())
 	feedbackChan <- res
 	fs.logger.Debug("filestream: post response", "response", res)
 	return nil
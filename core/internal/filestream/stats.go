@@ -0,0 +1,82 @@
+package filestream
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats describes the filestream's backlog of unsent data.
+//
+// It is meant for monitoring whether the filestream is keeping up
+// with the rate at which data is logged.
+type Stats struct {
+	// QueuedUpdates is the number of updates waiting to be processed.
+	QueuedUpdates int64
+
+	// BufferedLines is the number of history, system metrics and console
+	// lines that are waiting to be sent.
+	BufferedLines int64
+
+	// BufferedBytes is the total size of the buffered lines and summary.
+	BufferedBytes int64
+
+	// LastSuccessfulFlush is when the most recent request succeeded.
+	//
+	// It is the zero time if no request has succeeded yet.
+	LastSuccessfulFlush time.Time
+}
+
+// statsTracker collects Stats from multiple goroutines.
+type statsTracker struct {
+	queuedUpdates atomic.Int64
+	bufferedLines atomic.Int64
+	bufferedBytes atomic.Int64
+
+	// lastSuccessfulFlush is a Unix timestamp in nanoseconds, or 0.
+	lastSuccessfulFlush atomic.Int64
+}
+
+// Snapshot returns the current stats.
+func (st *statsTracker) Snapshot() Stats {
+	stats := Stats{
+		QueuedUpdates: st.queuedUpdates.Load(),
+		BufferedLines: st.bufferedLines.Load(),
+		BufferedBytes: st.bufferedBytes.Load(),
+	}
+
+	if lastFlush := st.lastSuccessfulFlush.Load(); lastFlush != 0 {
+		stats.LastSuccessfulFlush = time.Unix(0, lastFlush)
+	}
+
+	return stats
+}
+
+// SetBuffer records the data waiting to be sent.
+func (st *statsTracker) SetBuffer(buffer *FileStreamRequest) {
+	lines, bytes := buffer.size()
+	st.bufferedLines.Store(int64(lines))
+	st.bufferedBytes.Store(int64(bytes))
+}
+
+// RecordSuccessfulFlush records that a request succeeded at the given time.
+func (st *statsTracker) RecordSuccessfulFlush(t time.Time) {
+	st.lastSuccessfulFlush.Store(t.UnixNano())
+}
+
+// size returns the number of lines in the request and their total
+// size in bytes, including the summary.
+func (r *FileStreamRequest) size() (lines int, bytes int) {
+	for _, line := range r.HistoryLines {
+		bytes += len(line)
+	}
+	for _, line := range r.EventsLines {
+		bytes += len(line)
+	}
+	r.ConsoleLines.ForEach(func(_ int, line string) {
+		bytes += len(line)
+	})
+	bytes += len(r.LatestSummary)
+
+	lines = len(r.HistoryLines) + len(r.EventsLines) + r.ConsoleLines.Len()
+	return lines, bytes
+}
@@ -63,6 +63,10 @@ func (fs *FakeFileStream) Start(
 func (fs *FakeFileStream) FinishWithExit(int32) {}
 func (fs *FakeFileStream) FinishWithoutExit()   {}
 
+func (fs *FakeFileStream) Stats() filestream.Stats {
+	return filestream.Stats{}
+}
+
 func (fs *FakeFileStream) StreamUpdate(update filestream.Update) {
 	fs.Lock()
 	defer fs.Unlock()
@@ -10,7 +10,9 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/wandb/wandb/core/internal/hashencode"
 	"github.com/wandb/wandb/core/internal/observability"
+	"github.com/wandb/wandb/core/internal/randomid"
 	"github.com/wandb/wandb/core/internal/wboperation"
 )
 
@@ -110,28 +112,19 @@ func (ft *DefaultFileTransfer) Download(task *DefaultDownloadTask) error {
 		return err
 	}
 
-	// TODO: redo it to use the progress writer, to track the download progress
-	resp, err := ft.client.Get(task.Url)
+	req, err := retryablehttp.NewRequest(http.MethodGet, task.Url, nil)
 	if err != nil {
 		return err
 	}
-	task.Response = resp
+	if task.Context != nil {
+		req = req.WithContext(task.Context)
+	}
 
-	// open the file for writing and defer closing it
-	file, err := os.Create(task.Path)
+	resp, err := ft.client.Do(req)
 	if err != nil {
 		return err
 	}
-	defer func(file *os.File) {
-		if err := file.Close(); err != nil {
-			ft.logger.CaptureError(
-				fmt.Errorf(
-					"file transfer: download: error closing file %s: %v",
-					task.Path,
-					err,
-				))
-		}
-	}(file)
+	task.Response = resp
 
 	defer func(file io.ReadCloser) {
 		if err := file.Close(); err != nil {
@@ -143,11 +136,68 @@ func (ft *DefaultFileTransfer) Download(task *DefaultDownloadTask) error {
 		}
 	}(resp.Body)
 
-	_, err = io.Copy(file, resp.Body)
+	// Write to a temporary file first so that an interrupted or corrupted
+	// download never replaces the file at the destination.
+	tmpPath := fmt.Sprintf(
+		"%s.%s.partial",
+		task.Path,
+		randomid.GenerateAlphanumericSequence(8),
+	)
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer func() {
+		// This fails harmlessly if the file was renamed.
+		_ = os.Remove(tmpPath)
+	}()
+
+	total := task.Size
+	if total <= 0 {
+		total = resp.ContentLength
+	}
+	_, err = io.Copy(file, io.TeeReader(resp.Body, &progressWriter{
+		total:    int(total),
+		callback: task.ProgressCallback,
+	}))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if task.Digest != "" {
+		b64md5, err := hashencode.ComputeFileB64MD5(tmpPath)
+		if err != nil {
+			return err
+		}
+		if b64md5 != task.Digest {
+			return fmt.Errorf(
+				"file transfer: download: digest mismatch for %s: expected %s, got %s",
+				task.Path,
+				task.Digest,
+				b64md5,
+			)
+		}
+	}
+
+	return os.Rename(tmpPath, task.Path)
+}
+
+// progressWriter reports the number of bytes written to it.
+type progressWriter struct {
+	processed int
+	total     int
+	callback  func(processed, total int)
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.processed += len(p)
+	if w.callback != nil {
+		w.callback(w.processed, max(w.total, w.processed))
+	}
+	return len(p), nil
 }
 
 func getUploadRequestBody(
@@ -6,13 +6,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/hashencode"
 	"github.com/wandb/wandb/core/internal/observability"
 )
 
@@ -60,6 +63,88 @@ func TestDefaultFileTransfer_Download(t *testing.T) {
 	assert.Equal(t, task.Response.StatusCode, http.StatusOK)
 }
 
+func TestDefaultFileTransfer_DownloadVerifiesDigest(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("corrupted content"))
+		}))
+	defer mockServer.Close()
+	ft := filetransfer.NewDefaultFileTransfer(
+		retryablehttp.NewClient(),
+		observability.NewNoOpLogger(),
+		filetransfer.NewFileTransferStats(),
+	)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("original"), 0644))
+
+	err := ft.Download(&filetransfer.DefaultDownloadTask{
+		Path:   path,
+		Url:    mockServer.URL,
+		Digest: "expected-digest",
+	})
+
+	assert.ErrorContains(t, err, "digest mismatch")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file was not removed")
+}
+
+func TestDefaultFileTransfer_DownloadReportsProgress(t *testing.T) {
+	content := []byte("test content for download")
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(content)
+		}))
+	defer mockServer.Close()
+	ft := filetransfer.NewDefaultFileTransfer(
+		retryablehttp.NewClient(),
+		observability.NewNoOpLogger(),
+		filetransfer.NewFileTransferStats(),
+	)
+	var lastProcessed, lastTotal int
+
+	err := ft.Download(&filetransfer.DefaultDownloadTask{
+		Path:   filepath.Join(t.TempDir(), "file.txt"),
+		Url:    mockServer.URL,
+		Size:   int64(len(content)),
+		Digest: hashencode.ComputeB64MD5(content),
+		ProgressCallback: func(processed, total int) {
+			lastProcessed, lastTotal = processed, total
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, len(content), lastProcessed)
+	assert.Equal(t, len(content), lastTotal)
+}
+
+func TestDefaultFileTransfer_DownloadContextCancelled(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+	defer mockServer.Close()
+	ft := filetransfer.NewDefaultFileTransfer(
+		retryablehttp.NewClient(),
+		observability.NewNoOpLogger(),
+		filetransfer.NewFileTransferStats(),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ft.Download(&filetransfer.DefaultDownloadTask{
+		Path:    filepath.Join(t.TempDir(), "file.txt"),
+		Url:     mockServer.URL,
+		Context: ctx,
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestDefaultFileTransfer_Upload(t *testing.T) {
 	// Content to be uploaded
 	contentExpected := []byte("test content for upload")
@@ -46,6 +46,12 @@ type DefaultTask struct {
 	// ProgressCallback is a callback to execute on progress updates
 	ProgressCallback func(int, int)
 
+	// Digest is the expected base64-encoded MD5 hash of a downloaded file.
+	//
+	// If set, a download fails unless the data matches the digest, and the
+	// file at Path is left unchanged. Unused for uploads.
+	Digest string
+
 	// This can be used to cancel the file upload or download if it is no longer needed.
 	Context context.Context
 }
@@ -0,0 +1,142 @@
+// Package gqlhooks lets callers observe GraphQL requests made by the core.
+package gqlhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// Hooks is notified about every GraphQL request made through a client.
+//
+// Hooks are invoked synchronously on the goroutine making the request,
+// so they should return quickly.
+type Hooks interface {
+	// OnRequest is called before a request is sent.
+	OnRequest(info RequestInfo)
+
+	// OnResponse is called after a request completes, including retries.
+	OnResponse(info ResponseInfo)
+}
+
+// RequestInfo describes a GraphQL request.
+type RequestInfo struct {
+	// Context is the request's context.
+	//
+	// It can be used to correlate requests with the caller's traces.
+	Context context.Context
+
+	// OperationName is the name of the GraphQL query or mutation.
+	OperationName string
+
+	// Variables are the request's variables with sensitive values redacted.
+	//
+	// This is nil if the request has no variables.
+	Variables map[string]any
+}
+
+// ResponseInfo describes the outcome of a GraphQL request.
+type ResponseInfo struct {
+	RequestInfo
+
+	// StatusCode is the HTTP status code of the response.
+	//
+	// It is zero if the request failed without an HTTP response, such as
+	// due to a network error or a cancelled context.
+	StatusCode int
+
+	// Duration is how long the request took, including retries.
+	Duration time.Duration
+
+	// Err is the error returned by the request, if any.
+	Err error
+}
+
+// NewClient wraps a GraphQL client to invoke hooks around each request.
+func NewClient(delegate graphql.Client, hooks Hooks) graphql.Client {
+	switch {
+	case delegate == nil:
+		panic("gqlhooks: nil delegate")
+	case hooks == nil:
+		panic("gqlhooks: nil hooks")
+	}
+
+	return &client{delegate: delegate, hooks: hooks}
+}
+
+type client struct {
+	delegate graphql.Client
+	hooks    Hooks
+}
+
+func (c *client) MakeRequest(
+	ctx context.Context,
+	req *graphql.Request,
+	resp *graphql.Response,
+) error {
+	info := RequestInfo{
+		Context:       ctx,
+		OperationName: req.OpName,
+		Variables:     redactedVariables(req.Variables),
+	}
+
+	c.hooks.OnRequest(info)
+
+	start := time.Now()
+	err := c.delegate.MakeRequest(ctx, req, resp)
+
+	c.hooks.OnResponse(ResponseInfo{
+		RequestInfo: info,
+		StatusCode:  statusCode(resp, err),
+		Duration:    time.Since(start),
+		Err:         err,
+	})
+
+	return err
+}
+
+// statusCode returns the HTTP status code for the result of a request.
+func statusCode(resp *graphql.Response, err error) int {
+	var httpErr *graphql.HTTPError
+	switch {
+	case err == nil:
+		return http.StatusOK
+
+	case errors.As(err, &httpErr):
+		return httpErr.StatusCode
+
+	// GraphQL errors are returned with a successful HTTP status.
+	case resp != nil && len(resp.Errors) > 0:
+		return http.StatusOK
+
+	default:
+		return 0
+	}
+}
+
+// redactedVariables converts request variables to a map and redacts
+// sensitive values.
+func redactedVariables(variables any) map[string]any {
+	if variables == nil {
+		return nil
+	}
+
+	// Variables are generated structs, so a JSON round-trip gives us
+	// the same names that are sent to the server.
+	encoded, err := json.Marshal(variables)
+	if err != nil {
+		return nil
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil
+	}
+
+	redact(result)
+	return result
+}
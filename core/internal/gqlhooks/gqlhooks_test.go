@@ -0,0 +1,104 @@
+package gqlhooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wandb/wandb/core/internal/gqlhooks"
+	"github.com/wandb/wandb/core/internal/gqlmock"
+)
+
+type recordingHooks struct {
+	requests  []gqlhooks.RequestInfo
+	responses []gqlhooks.ResponseInfo
+}
+
+func (h *recordingHooks) OnRequest(info gqlhooks.RequestInfo) {
+	h.requests = append(h.requests, info)
+}
+
+func (h *recordingHooks) OnResponse(info gqlhooks.ResponseInfo) {
+	h.responses = append(h.responses, info)
+}
+
+type testVariables struct {
+	Name   string         `json:"name"`
+	ApiKey string         `json:"apiKey"`
+	Nested map[string]any `json:"nested"`
+}
+
+func makeRequest(t *testing.T, client graphql.Client) error {
+	t.Helper()
+
+	var data map[string]any
+	return client.MakeRequest(
+		context.Background(),
+		&graphql.Request{
+			OpName: "TestOp",
+			Query:  "query TestOp { x }",
+			Variables: testVariables{
+				Name:   "run",
+				ApiKey: "my-secret-key",
+				Nested: map[string]any{
+					"access_token": "abc",
+					"other":        "value",
+				},
+			},
+		},
+		&graphql.Response{Data: &data},
+	)
+}
+
+func TestHooks_RedactsVariables(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubAnyOnce(`{"x": 1}`)
+	hooks := &recordingHooks{}
+
+	err := makeRequest(t, gqlhooks.NewClient(mockGQL, hooks))
+
+	require.NoError(t, err)
+	require.Len(t, hooks.requests, 1)
+	require.Len(t, hooks.responses, 1)
+	expected := map[string]any{
+		"name":   "run",
+		"apiKey": "<redacted>",
+		"nested": map[string]any{
+			"access_token": "<redacted>",
+			"other":        "value",
+		},
+	}
+	assert.Equal(t, "TestOp", hooks.requests[0].OperationName)
+	assert.Equal(t, expected, hooks.requests[0].Variables)
+	assert.Equal(t, expected, hooks.responses[0].Variables)
+	assert.Equal(t, 200, hooks.responses[0].StatusCode)
+	assert.NoError(t, hooks.responses[0].Err)
+}
+
+func TestHooks_HTTPError(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchWithError(gomock.Any(), &graphql.HTTPError{StatusCode: 400})
+	hooks := &recordingHooks{}
+
+	err := makeRequest(t, gqlhooks.NewClient(mockGQL, hooks))
+
+	require.Error(t, err)
+	require.Len(t, hooks.responses, 1)
+	assert.Equal(t, 400, hooks.responses[0].StatusCode)
+	assert.Equal(t, err, hooks.responses[0].Err)
+}
+
+func TestHooks_NetworkError(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchWithError(gomock.Any(), errors.New("connection refused"))
+	hooks := &recordingHooks{}
+
+	_ = makeRequest(t, gqlhooks.NewClient(mockGQL, hooks))
+
+	require.Len(t, hooks.responses, 1)
+	assert.Zero(t, hooks.responses[0].StatusCode)
+}
@@ -0,0 +1,53 @@
+package gqlhooks
+
+import "strings"
+
+// redactedValue replaces sensitive values in request variables.
+const redactedValue = "<redacted>"
+
+// sensitiveKeyParts are substrings of variable names whose values
+// must not be passed to hooks.
+//
+// Names are compared in lowercase with underscores and dashes removed.
+var sensitiveKeyParts = []string{
+	"apikey",
+	"password",
+	"secret",
+	"token",
+	"credential",
+	"authorization",
+}
+
+// redact replaces sensitive values in a decoded JSON value in place.
+func redact(value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if isSensitiveKey(key) {
+				value[key] = redactedValue
+			} else {
+				redact(child)
+			}
+		}
+
+	case []any:
+		for _, child := range value {
+			redact(child)
+		}
+	}
+}
+
+// isSensitiveKey reports whether a variable name looks like it holds
+// a secret.
+func isSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").
+		Replace(strings.ToLower(key))
+
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,151 @@
+package runconfig
+
+import (
+	"math"
+	"reflect"
+	"slices"
+)
+
+// Diff describes how the top-level keys of a config changed.
+//
+// Each list of keys is sorted.
+type Diff struct {
+	// Added are keys that are only in the new config.
+	Added []string
+
+	// Removed are keys that are only in the old config.
+	Removed []string
+
+	// Changed are keys that are in both configs with different values.
+	Changed []string
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the config to a new config given as nested maps.
+//
+// Nested maps and lists are compared by value. Numbers compare equal
+// if they have the same value regardless of their type, so that an int64
+// equals a float64 with the same value as happens when parsing JSON.
+func (rc *RunConfig) Diff(newConfig map[string]any) Diff {
+	oldConfig := rc.pathTree.CloneTree()
+	diff := Diff{}
+
+	for key, newValue := range newConfig {
+		oldValue, ok := oldConfig[key]
+
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, key)
+		case !valuesEqual(oldValue, newValue):
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+
+	for key := range oldConfig {
+		if _, ok := newConfig[key]; !ok {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Removed)
+	slices.Sort(diff.Changed)
+	return diff
+}
+
+// valuesEqual deeply compares two config values.
+func valuesEqual(a, b any) bool {
+	if aNum, bNum, ok := asNumbers(a, b); ok {
+		return aNum.equals(bNum)
+	}
+
+	switch aValue := a.(type) {
+	case map[string]any:
+		bValue, ok := b.(map[string]any)
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+
+		for key, aElem := range aValue {
+			bElem, ok := bValue[key]
+			if !ok || !valuesEqual(aElem, bElem) {
+				return false
+			}
+		}
+
+		return true
+
+	case []any:
+		bValue, ok := b.([]any)
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+
+		for i := range aValue {
+			if !valuesEqual(aValue[i], bValue[i]) {
+				return false
+			}
+		}
+
+		return true
+
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// number is a numeric config value.
+type number struct {
+	isInt bool
+	i     int64
+	f     float64
+}
+
+func (n number) equals(other number) bool {
+	if n.isInt && other.isInt {
+		return n.i == other.i
+	}
+
+	return n.float() == other.float()
+}
+
+func (n number) float() float64 {
+	if n.isInt {
+		return float64(n.i)
+	}
+	return n.f
+}
+
+// asNumbers converts both values to numbers if they're numeric.
+func asNumbers(a, b any) (number, number, bool) {
+	aNum, aOK := asNumber(a)
+	bNum, bOK := asNumber(b)
+	return aNum, bNum, aOK && bOK
+}
+
+func asNumber(x any) (number, bool) {
+	value := reflect.ValueOf(x)
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{isInt: true, i: value.Int()}, true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		u := value.Uint()
+		if u > math.MaxInt64 {
+			return number{f: float64(u)}, true
+		}
+		return number{isInt: true, i: int64(u)}, true
+
+	case reflect.Float32, reflect.Float64:
+		return number{f: value.Float()}, true
+
+	default:
+		return number{}, false
+	}
+}
@@ -150,3 +150,68 @@ func TestCloneTree(t *testing.T) {
 		runConfig.CloneTree(),
 	)
 }
+
+func TestDiff(t *testing.T) {
+	runConfig := runconfig.NewFrom(map[string]any{
+		"unchanged": "x",
+		"changed":   1,
+		"removed":   true,
+		"nested": map[string]any{
+			"a": int64(1),
+			"b": []any{int64(2), "three"},
+		},
+	})
+
+	diff := runConfig.Diff(map[string]any{
+		"unchanged": "x",
+		"changed":   2,
+		"added":     false,
+		"nested": map[string]any{
+			"a": 1.0,
+			"b": []any{2.0, "three"},
+		},
+	})
+
+	assert.Equal(t,
+		runconfig.Diff{
+			Added:   []string{"added"},
+			Removed: []string{"removed"},
+			Changed: []string{"changed"},
+		},
+		diff,
+	)
+}
+
+func TestDiff_NestedChange(t *testing.T) {
+	runConfig := runconfig.NewFrom(map[string]any{
+		"nested": map[string]any{"a": map[string]any{"b": 1}},
+	})
+
+	diff := runConfig.Diff(map[string]any{
+		"nested": map[string]any{"a": map[string]any{"b": 1.5}},
+	})
+
+	assert.Equal(t, []string{"nested"}, diff.Changed)
+}
+
+func TestDiff_NumericTypes(t *testing.T) {
+	runConfig := runconfig.NewFrom(map[string]any{
+		"int": int64(3),
+		"big": int64(1<<62 + 1),
+	})
+
+	diff := runConfig.Diff(map[string]any{
+		"int": 3.0,
+		"big": int64(1 << 62),
+	})
+
+	assert.Equal(t, []string{"big"}, diff.Changed)
+}
+
+func TestDiff_Empty(t *testing.T) {
+	runConfig := runconfig.NewFrom(map[string]any{"a": "b"})
+
+	diff := runConfig.Diff(map[string]any{"a": "b"})
+
+	assert.True(t, diff.IsEmpty())
+}
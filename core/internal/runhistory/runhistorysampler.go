@@ -1,24 +1,63 @@
 package runhistory
 
 import (
+	"hash/fnv"
 	"math/rand/v2"
+	"slices"
+	"strings"
 
 	"github.com/wandb/wandb/core/internal/pathtree"
 	"github.com/wandb/wandb/core/internal/sampler"
 	spb "github.com/wandb/wandb/core/pkg/service_go_proto"
 )
 
+// defaultSampleSize is the number of values kept per metric by default.
+const defaultSampleSize = 48
+
 // RunHistorySampler tracks a sample of each metric in the run's history.
 type RunHistorySampler struct {
-	rand    *rand.Rand
+	seed    uint64
 	samples map[string]*sampler.ReservoirSampler[float32]
+
+	defaultSampleSize int
+	sampleSizes       map[string]int
+}
+
+type RunHistorySamplerParams struct {
+	// DefaultSampleSize is the maximum number of values to keep per metric.
+	//
+	// Metrics logged fewer times have all their values kept.
+	// If zero, a default is used.
+	DefaultSampleSize int
+
+	// SampleSizes overrides DefaultSampleSize for specific metrics.
+	SampleSizes map[string]int
+
+	// Seed makes sampling deterministic if nonzero.
+	//
+	// Samplers with the same seed select the same values from the same
+	// sequence of history rows. If zero, a random seed is used.
+	Seed uint64
 }
 
-func NewRunHistorySampler() *RunHistorySampler {
-	return &RunHistorySampler{
-		rand:    rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+func NewRunHistorySampler(params RunHistorySamplerParams) *RunHistorySampler {
+	s := &RunHistorySampler{
+		seed:    params.Seed,
 		samples: make(map[string]*sampler.ReservoirSampler[float32]),
+
+		defaultSampleSize: params.DefaultSampleSize,
+		sampleSizes:       params.SampleSizes,
+	}
+
+	if s.seed == 0 {
+		s.seed = rand.Uint64()
+	}
+
+	if s.defaultSampleSize <= 0 {
+		s.defaultSampleSize = defaultSampleSize
 	}
+
+	return s
 }
 
 // SampleNext updates all samples with the next history row.
@@ -36,7 +75,10 @@ func (s *RunHistorySampler) SampleNext(history *RunHistory) {
 
 			sample, ok := s.samples[key]
 			if !ok {
-				sample = sampler.NewReservoirSampler[float32](s.rand, 48)
+				sample = sampler.NewReservoirSampler[float32](
+					s.randForKey(key),
+					s.sampleSize(key),
+				)
 				s.samples[key] = sample
 			}
 			sample.Add(float32(value))
@@ -46,7 +88,26 @@ func (s *RunHistorySampler) SampleNext(history *RunHistory) {
 	)
 }
 
-// Get returns all the samples.
+// randForKey returns the source of randomness for sampling a metric.
+//
+// Each metric gets its own source so that the sample doesn't depend on
+// the order in which metrics are visited.
+func (s *RunHistorySampler) randForKey(key string) *rand.Rand {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	return rand.New(rand.NewPCG(s.seed, hash.Sum64()))
+}
+
+// sampleSize returns the number of values to keep for the metric.
+func (s *RunHistorySampler) sampleSize(key string) int {
+	if size, ok := s.sampleSizes[key]; ok && size > 0 {
+		return size
+	}
+
+	return s.defaultSampleSize
+}
+
+// Get returns all the samples, sorted by metric key.
 func (s *RunHistorySampler) Get() []*spb.SampledHistoryItem {
 	items := make([]*spb.SampledHistoryItem, 0, len(s.samples))
 
@@ -58,5 +119,9 @@ func (s *RunHistorySampler) Get() []*spb.SampledHistoryItem {
 			})
 	}
 
+	slices.SortFunc(items, func(a, b *spb.SampledHistoryItem) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
 	return items
 }
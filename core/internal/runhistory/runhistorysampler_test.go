@@ -0,0 +1,66 @@
+package runhistory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wandb/wandb/core/internal/pathtree"
+	"github.com/wandb/wandb/core/internal/runhistory"
+)
+
+// sampleSequence samples 'x' logged n times and 'y' logged twice.
+func sampleSequence(
+	params runhistory.RunHistorySamplerParams,
+	n int,
+) *runhistory.RunHistorySampler {
+	s := runhistory.NewRunHistorySampler(params)
+
+	for i := range n {
+		rh := runhistory.New()
+		rh.SetInt(pathtree.PathOf("x"), int64(i))
+		if i < 2 {
+			rh.SetInt(pathtree.PathOf("y"), int64(i))
+		}
+		s.SampleNext(rh)
+	}
+
+	return s
+}
+
+func TestSampler_PerKeySampleSize(t *testing.T) {
+	s := sampleSequence(runhistory.RunHistorySamplerParams{
+		DefaultSampleSize: 5,
+		SampleSizes:       map[string]int{"x": 3},
+	}, 1000)
+
+	items := s.Get()
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "x", items[0].Key)
+	assert.Len(t, items[0].ValuesFloat, 3)
+	assert.Equal(t, "y", items[1].Key)
+	assert.Equal(t, []float32{0, 1}, items[1].ValuesFloat)
+}
+
+func TestSampler_DeterministicWithSeed(t *testing.T) {
+	params := func() runhistory.RunHistorySamplerParams {
+		return runhistory.RunHistorySamplerParams{
+			DefaultSampleSize: 10,
+			Seed:              12345,
+		}
+	}
+
+	items1 := sampleSequence(params(), 1000).Get()
+	items2 := sampleSequence(params(), 1000).Get()
+
+	assert.Equal(t, items1, items2)
+}
+
+func TestSampler_DefaultSampleSize(t *testing.T) {
+	s := sampleSequence(runhistory.RunHistorySamplerParams{}, 1000)
+
+	items := s.Get()
+
+	assert.Len(t, items[0].ValuesFloat, 48)
+}
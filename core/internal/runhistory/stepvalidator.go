@@ -0,0 +1,64 @@
+package runhistory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wandb/wandb/core/internal/pathtree"
+)
+
+// StepValidator detects metrics logged at a step that's not after
+// the step at which they were last logged.
+//
+// Logging a metric at the same step twice overwrites the first value,
+// and logging it at an earlier step makes it out of order in charts.
+// Neither is an error, but it's often a bug.
+type StepValidator struct {
+	// lastSteps is the last step at which each metric was logged.
+	lastSteps *pathtree.PathTree[int64]
+}
+
+func NewStepValidator() *StepValidator {
+	return &StepValidator{lastSteps: pathtree.New[int64]()}
+}
+
+// StepWarning is a metric that was logged at a non-increasing step.
+type StepWarning struct {
+	// Path is the metric's key.
+	Path pathtree.TreePath
+
+	// Step is the step at which the metric was just logged.
+	Step int64
+
+	// LastStep is the step at which the metric was previously logged.
+	LastStep int64
+}
+
+func (w *StepWarning) Error() string {
+	key := strings.Join(w.Path.Labels(), ".")
+
+	if w.Step == w.LastStep {
+		return fmt.Sprintf(
+			"metric %q was logged more than once at step %d",
+			key, w.Step)
+	} else {
+		return fmt.Sprintf(
+			"metric %q was logged at step %d after step %d",
+			key, w.Step, w.LastStep)
+	}
+}
+
+// Observe records that a metric was logged at a step.
+//
+// Returns a warning if the step is not greater than the last step
+// at which the metric was observed, or nil otherwise.
+func (v *StepValidator) Observe(path pathtree.TreePath, step int64) *StepWarning {
+	lastStep, seen := v.lastSteps.GetLeaf(path)
+	v.lastSteps.Set(path, max(step, lastStep))
+
+	if seen && step <= lastStep {
+		return &StepWarning{Path: path, Step: step, LastStep: lastStep}
+	}
+
+	return nil
+}
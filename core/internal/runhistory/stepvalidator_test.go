@@ -0,0 +1,45 @@
+package runhistory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wandb/wandb/core/internal/pathtree"
+	"github.com/wandb/wandb/core/internal/runhistory"
+)
+
+func TestStepValidator_IncreasingSteps(t *testing.T) {
+	v := runhistory.NewStepValidator()
+
+	assert.Nil(t, v.Observe(pathtree.PathOf("x"), 1))
+	assert.Nil(t, v.Observe(pathtree.PathOf("x"), 2))
+	assert.Nil(t, v.Observe(pathtree.PathOf("y"), 1))
+}
+
+func TestStepValidator_RepeatedStep(t *testing.T) {
+	v := runhistory.NewStepValidator()
+
+	_ = v.Observe(pathtree.PathOf("a", "b"), 3)
+	warning := v.Observe(pathtree.PathOf("a", "b"), 3)
+
+	require.NotNil(t, warning)
+	assert.EqualValues(t, 3, warning.Step)
+	assert.EqualValues(t, 3, warning.LastStep)
+	assert.Equal(t,
+		`metric "a.b" was logged more than once at step 3`,
+		warning.Error())
+}
+
+func TestStepValidator_DecreasingStep(t *testing.T) {
+	v := runhistory.NewStepValidator()
+
+	_ = v.Observe(pathtree.PathOf("x"), 5)
+	warning := v.Observe(pathtree.PathOf("x"), 2)
+	nextWarning := v.Observe(pathtree.PathOf("x"), 4)
+
+	require.NotNil(t, warning)
+	assert.Equal(t, `metric "x" was logged at step 2 after step 5`,
+		warning.Error())
+	assert.NotNil(t, nextWarning, "last step should remain 5")
+}
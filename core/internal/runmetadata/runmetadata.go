@@ -52,6 +52,9 @@ type RunMetadata struct {
 	config    *runconfig.RunConfig
 	telemetry *spb.TelemetryRecord
 	metrics   *runmetric.RunConfigMetrics
+
+	// configHash is a hash of the serialized config, for Snapshot.
+	configHash string
 }
 
 type RunMetadataParams struct {
@@ -145,6 +148,7 @@ func InitRun(
 		telemetry: telemetry,
 		metrics:   metrics,
 	}
+	metadata.lockedUpdateConfigHash()
 
 	operation := metadata.operations.New("creating run")
 	defer operation.Finish()
@@ -261,6 +265,15 @@ func (metadata *RunMetadata) ConfigMap() map[string]any {
 	return metadata.config.CloneTree()
 }
 
+// ConfigDiff returns the top-level config keys that differ in newConfig.
+//
+// This is used to upload only the parts of the config that changed.
+func (metadata *RunMetadata) ConfigDiff(newConfig map[string]any) runconfig.Diff {
+	metadata.mu.Lock()
+	defer metadata.mu.Unlock()
+	return metadata.config.Diff(newConfig)
+}
+
 func (metadata *RunMetadata) StartTime() time.Time {
 	metadata.mu.Lock()
 	defer metadata.mu.Unlock()
@@ -293,6 +306,16 @@ func (metadata *RunMetadata) Finish() {
 	metadata.wg.Wait()
 }
 
+// IsFinished reports whether Finish has been called.
+func (metadata *RunMetadata) IsFinished() bool {
+	select {
+	case <-metadata.done:
+		return true
+	default:
+		return false
+	}
+}
+
 // syncPeriodically uploads changes in a loop.
 func (metadata *RunMetadata) syncPeriodically() {
 	// TODO: Loop forever, uploading changes as they arrive.
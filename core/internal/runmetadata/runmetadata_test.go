@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wandb/wandb/core/internal/featurechecker"
 	"github.com/wandb/wandb/core/internal/gqlmock"
 	"github.com/wandb/wandb/core/internal/observability"
@@ -198,3 +199,101 @@ func TestInitRun_Offline(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, metadata)
 }
+
+func TestSnapshot(t *testing.T) {
+	metadata, err := runmetadata.InitRun(
+		runRecord(&spb.RunRecord{
+			RunId:       "run ID",
+			Project:     "project name",
+			Entity:      "entity name",
+			DisplayName: "display name",
+			Tags:        []string{"tag1", "tag2"},
+		}),
+		testParams(),
+	)
+	require.NoError(t, err)
+	defer metadata.Finish()
+
+	snapshot := metadata.Snapshot()
+
+	assert.Equal(t, "run ID", snapshot.RunID)
+	assert.Equal(t, "project name", snapshot.Project)
+	assert.Equal(t, "entity name", snapshot.Entity)
+	assert.Equal(t, "display name", snapshot.DisplayName)
+	assert.Equal(t, []string{"tag1", "tag2"}, snapshot.Tags)
+	assert.NotEmpty(t, snapshot.ConfigHash)
+}
+
+func TestSnapshot_DoesNotAlias(t *testing.T) {
+	metadata, err := runmetadata.InitRun(
+		runRecord(&spb.RunRecord{Tags: []string{"tag1"}}),
+		testParams(),
+	)
+	require.NoError(t, err)
+	defer metadata.Finish()
+
+	snapshot1 := metadata.Snapshot()
+	snapshot1.Tags[0] = "modified"
+	snapshot2 := metadata.Snapshot()
+
+	assert.Equal(t, []string{"tag1"}, snapshot2.Tags)
+}
+
+func TestSnapshot_ConfigHashDependsOnConfig(t *testing.T) {
+	configRecord := func(valueJSON string) *spb.ConfigRecord {
+		return &spb.ConfigRecord{
+			Update: []*spb.ConfigItem{{Key: "x", ValueJson: valueJSON}},
+		}
+	}
+	metadata1, err := runmetadata.InitRun(
+		runRecord(&spb.RunRecord{Config: configRecord(`1`)}),
+		testParams(),
+	)
+	require.NoError(t, err)
+	defer metadata1.Finish()
+	metadata2, err := runmetadata.InitRun(
+		runRecord(&spb.RunRecord{Config: configRecord(`1`)}),
+		testParams(),
+	)
+	require.NoError(t, err)
+	defer metadata2.Finish()
+	metadata3, err := runmetadata.InitRun(
+		runRecord(&spb.RunRecord{Config: configRecord(`2`)}),
+		testParams(),
+	)
+	require.NoError(t, err)
+	defer metadata3.Finish()
+
+	assert.Equal(t,
+		metadata1.Snapshot().ConfigHash,
+		metadata2.Snapshot().ConfigHash)
+	assert.NotEqual(t,
+		metadata1.Snapshot().ConfigHash,
+		metadata3.Snapshot().ConfigHash)
+}
+
+func TestConfigDiff(t *testing.T) {
+	metadata, err := runmetadata.InitRun(
+		runRecord(&spb.RunRecord{
+			Config: &spb.ConfigRecord{
+				Update: []*spb.ConfigItem{
+					{Key: "lr", ValueJson: `0.1`},
+					{Key: "epochs", ValueJson: `10`},
+				},
+			},
+		}),
+		testParams(),
+	)
+	require.NoError(t, err)
+	defer metadata.Finish()
+	newConfig := metadata.ConfigMap()
+	newConfig["lr"] = 0.01
+	newConfig["epochs"] = 10.0
+	newConfig["batch_size"] = 32
+
+	diff := metadata.ConfigDiff(newConfig)
+
+	assert.Equal(t, []string{"batch_size"}, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Equal(t, []string{"lr"}, diff.Changed)
+}
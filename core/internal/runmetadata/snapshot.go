@@ -0,0 +1,59 @@
+package runmetadata
+
+import (
+	"slices"
+
+	"github.com/wandb/wandb/core/internal/hashencode"
+	"github.com/wandb/wandb/core/internal/runconfig"
+)
+
+// Snapshot is a copy of a run's identifying information.
+//
+// It does not share any memory with the RunMetadata it came from,
+// so it can be read freely without synchronization.
+type Snapshot struct {
+	RunID       string
+	Project     string
+	Entity      string
+	DisplayName string
+	Tags        []string
+
+	// ConfigHash is a hex-encoded hash of the run's config.
+	//
+	// It changes whenever the config changes.
+	ConfigHash string
+}
+
+// Snapshot returns a copy of the run's identifying information.
+func (metadata *RunMetadata) Snapshot() Snapshot {
+	metadata.mu.Lock()
+	defer metadata.mu.Unlock()
+
+	return Snapshot{
+		RunID:       metadata.params.RunID,
+		Project:     metadata.params.Project,
+		Entity:      metadata.params.Entity,
+		DisplayName: metadata.params.DisplayName,
+		Tags:        slices.Clone(metadata.params.Tags),
+		ConfigHash:  metadata.configHash,
+	}
+}
+
+// lockedUpdateConfigHash recomputes the cached config hash.
+//
+// It must be called after every modification to the config.
+// The mutex must be held.
+func (metadata *RunMetadata) lockedUpdateConfigHash() {
+	// YAML is used because it serializes map keys in sorted order.
+	serializedConfig, err := metadata.config.Serialize(runconfig.FormatYaml)
+	if err != nil {
+		metadata.logger.Error(
+			"runmetadata: failed to serialize config for hashing",
+			"error", err,
+		)
+		metadata.configHash = ""
+		return
+	}
+
+	metadata.configHash = hashencode.ComputeHexMD5(serializedConfig)
+}
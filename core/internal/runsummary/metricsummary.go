@@ -21,6 +21,9 @@ type metricSummary struct {
 	// noSummary disables any summary output for the metric at all.
 	noSummary bool
 
+	// updateMode is how explicit updates combine with the latest value.
+	updateMode UpdateMode
+
 	// hasData is whether any summary data has been accumulated.
 	hasData bool
 }
@@ -29,11 +29,25 @@ func (rs *RunSummary) SetFromRecord(record *spb.SummaryItem) error {
 		return fmt.Errorf("runsummary: invalid summary JSON: %v", err)
 	}
 
-	rs.getOrMakeSummary(keyPath(record)).SetExplicit(value)
+	summary := rs.getOrMakeSummary(keyPath(record))
+	if summary.hasData && summary.updateMode != UpdateOverwrite {
+		value, err = summary.updateMode.combine(summary.latest, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	summary.SetExplicit(value)
 
 	return nil
 }
 
+// SetUpdateMode configures how explicit updates to a metric's
+// summary combine with its existing value.
+func (rs *RunSummary) SetUpdateMode(path pathtree.TreePath, mode UpdateMode) {
+	rs.getOrMakeSummary(path).updateMode = mode
+}
+
 func (rs *RunSummary) RemoveFromRecord(record *spb.SummaryItem) {
 	if len(record.NestedKey) > 0 {
 		rs.Remove(
@@ -187,3 +187,51 @@ func TestSerialize(t *testing.T) {
 		}`,
 		string(encoded))
 }
+
+func TestUpdateMode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mode     runsummary.UpdateMode
+		values   []string
+		expected string
+	}{
+		{"overwrite", runsummary.UpdateOverwrite, []string{"1", "3", "2"}, "2"},
+		{"min", runsummary.UpdateMin, []string{"2", "1", "3"}, "1"},
+		{"max", runsummary.UpdateMax, []string{"1", "3", "2"}, "3"},
+		{"sum", runsummary.UpdateSum, []string{"1", "3", "2"}, "6"},
+		{"max float", runsummary.UpdateMax, []string{"1", "2.5", "2"}, "2.5"},
+		{"overwrite mixed", runsummary.UpdateOverwrite, []string{"1", `"a"`}, `"a"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := runsummary.New()
+			rs.SetUpdateMode(pathtree.PathOf("x"), tc.mode)
+
+			for _, value := range tc.values {
+				require.NoError(t,
+					rs.SetFromRecord(&spb.SummaryItem{
+						Key:       "x",
+						ValueJson: value,
+					}))
+			}
+
+			encoded, err := rs.Serialize()
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"x": `+tc.expected+`}`, string(encoded))
+		})
+	}
+}
+
+func TestUpdateMode_MixedTypes(t *testing.T) {
+	rs := runsummary.New()
+	rs.SetUpdateMode(pathtree.PathOf("x"), runsummary.UpdateMax)
+
+	require.NoError(t,
+		rs.SetFromRecord(&spb.SummaryItem{Key: "x", ValueJson: `"abc"`}))
+	err := rs.SetFromRecord(&spb.SummaryItem{Key: "x", ValueJson: "1"})
+
+	assert.ErrorContains(t, err, "cannot apply max update")
+	encoded, _ := rs.Serialize()
+	assert.JSONEq(t, `{"x": "abc"}`, string(encoded))
+}
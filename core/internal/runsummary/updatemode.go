@@ -0,0 +1,90 @@
+package runsummary
+
+import "fmt"
+
+// UpdateMode is how an explicit summary update combines with the
+// metric's existing summary value.
+type UpdateMode uint8
+
+const (
+	// UpdateOverwrite replaces the existing value.
+	UpdateOverwrite UpdateMode = iota
+
+	// UpdateMin keeps the smaller of the existing and new values.
+	UpdateMin
+
+	// UpdateMax keeps the larger of the existing and new values.
+	UpdateMax
+
+	// UpdateSum adds the new value to the existing value.
+	UpdateSum
+)
+
+func (m UpdateMode) String() string {
+	switch m {
+	case UpdateOverwrite:
+		return "overwrite"
+	case UpdateMin:
+		return "min"
+	case UpdateMax:
+		return "max"
+	case UpdateSum:
+		return "sum"
+	default:
+		return fmt.Sprintf("UpdateMode(%d)", uint8(m))
+	}
+}
+
+// combine returns the result of applying an update to an existing value.
+//
+// Both values must be numbers unless the mode is UpdateOverwrite.
+// Integers are kept as integers unless combined with a float.
+func (m UpdateMode) combine(old, new any) (any, error) {
+	if m == UpdateOverwrite {
+		return new, nil
+	}
+
+	oldInt, oldIsInt := old.(int64)
+	newInt, newIsInt := new.(int64)
+	if oldIsInt && newIsInt {
+		switch m {
+		case UpdateMin:
+			return min(oldInt, newInt), nil
+		case UpdateMax:
+			return max(oldInt, newInt), nil
+		case UpdateSum:
+			return oldInt + newInt, nil
+		}
+	}
+
+	oldFloat, oldOK := asFloat(old)
+	newFloat, newOK := asFloat(new)
+	if !oldOK || !newOK {
+		return nil, fmt.Errorf(
+			"runsummary: cannot apply %v update to %T and %T",
+			m, old, new)
+	}
+
+	switch m {
+	case UpdateMin:
+		return min(oldFloat, newFloat), nil
+	case UpdateMax:
+		return max(oldFloat, newFloat), nil
+	case UpdateSum:
+		return oldFloat + newFloat, nil
+	default:
+		return nil, fmt.Errorf("runsummary: unknown update mode %v", m)
+	}
+}
+
+// asFloat converts a parsed JSON number to a float64.
+func asFloat(value any) (float64, bool) {
+	switch x := value.(type) {
+	case int64:
+		return float64(x), true
+	case float64:
+		return x, true
+	default:
+		return 0, false
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/wandb/wandb/core/internal/observability"
+	"github.com/wandb/wandb/core/internal/waiting"
 )
 
 var errRecordAfterClose = errors.New("runwork: ignoring record after close")
@@ -79,12 +80,29 @@ type runWork struct {
 	endCtxCancel func()
 
 	logger *observability.CoreLogger
+	clock  waiting.Clock
 }
 
-func New(bufferSize int, logger *observability.CoreLogger) RunWork {
+// Option configures optional RunWork behavior.
+type Option func(*runWork)
+
+// WithClock sets the clock used to detect slow AddWork calls.
+//
+// It defaults to the real clock.
+func WithClock(clock waiting.Clock) Option {
+	return func(rw *runWork) {
+		rw.clock = clock
+	}
+}
+
+func New(
+	bufferSize int,
+	logger *observability.CoreLogger,
+	opts ...Option,
+) RunWork {
 	endCtx, endCtxCancel := context.WithCancel(context.Background())
 
-	return &runWork{
+	rw := &runWork{
 		addWorkCV:    sync.NewCond(&sync.Mutex{}),
 		closed:       make(chan struct{}),
 		done:         make(chan struct{}),
@@ -92,7 +110,14 @@ func New(bufferSize int, logger *observability.CoreLogger) RunWork {
 		endCtx:       endCtx,
 		endCtxCancel: endCtxCancel,
 		logger:       logger,
+		clock:        waiting.NewRealClock(),
 	}
+
+	for _, opt := range opts {
+		opt(rw)
+	}
+
+	return rw
 }
 
 func (rw *runWork) incAddWork() {
@@ -148,34 +173,41 @@ func (rw *runWork) AddWorkOrCancel(
 		"work", work.DebugInfo(),
 		"buffer", len(rw.internalWork))
 
-	start := time.Now()
+	start := rw.clock.Now()
 	for i := 0; ; i++ {
+		slowWarning := rw.clock.NewTimer(10 * time.Minute)
+
 		select {
 		// Detect deadlocks and hangs that prevent internalWork
 		// from flushing.
-		case <-time.After(10 * time.Minute):
+		case <-slowWarning.C():
 			// Stop warning after the first hour to minimize spam.
 			if i < 6 {
 				rw.logger.CaptureWarn(
 					"runwork: taking a long time",
-					"seconds", time.Since(start).Seconds(),
+					"seconds", rw.clock.Now().Sub(start).Seconds(),
 					"work", work.DebugInfo(),
 				)
 			}
 
 		case <-rw.closed:
+			slowWarning.Stop()
+
 			// Here, Close() must have been called, so we should drop the record.
 			rw.logger.CaptureError(errRecordAfterClose, "work", work)
 			return
 
 		case <-cancel:
+			slowWarning.Stop()
 			return
 
 		case rw.internalWork <- work:
+			slowWarning.Stop()
+
 			if i > 0 {
 				rw.logger.CaptureInfo(
 					"runwork: succeeded after taking longer than expected",
-					"seconds", time.Since(start).Seconds(),
+					"seconds", rw.clock.Now().Sub(start).Seconds(),
 					"work", work.DebugInfo(),
 				)
 			}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/wandb/wandb/core/internal/observability"
 	"github.com/wandb/wandb/core/internal/runwork"
+	"github.com/wandb/wandb/core/internal/waitingtest"
 	spb "github.com/wandb/wandb/core/pkg/service_go_proto"
 )
 
@@ -73,6 +74,31 @@ func TestCloseDuringAddWork(t *testing.T) {
 	assert.Contains(t, logs.String(), "runwork: ignoring record after close")
 }
 
+func TestAddWork_WarnsIfSlow(t *testing.T) {
+	logs := bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{}))
+	clock := waitingtest.NewFakeClock(time.Unix(0, 0))
+	rw := runwork.New(0,
+		observability.NewCoreLogger(logger, nil),
+		runwork.WithClock(clock))
+
+	addWorkDone := make(chan struct{})
+	go func() {
+		defer close(addWorkDone)
+		rw.AddWork(runwork.WorkFromRecord(&spb.Record{}))
+	}()
+	clock.WaitForTimers(t, 1, time.Second)
+	clock.Advance(10 * time.Minute)
+	clock.WaitForTimers(t, 1, time.Second) // AddWork loops after warning
+	<-rw.Chan()
+	<-addWorkDone
+
+	assert.Contains(t, logs.String(), "runwork: taking a long time")
+	assert.Contains(t, logs.String(),
+		"runwork: succeeded after taking longer than expected")
+	assert.Contains(t, logs.String(), "seconds=600")
+}
+
 func TestCloseAfterClose(t *testing.T) {
 	rw := runwork.New(0, observability.NewNoOpLogger())
 
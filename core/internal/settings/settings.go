@@ -542,6 +542,11 @@ func (s *Settings) UpdateRunID(runID string) {
 	s.Proto.RunId = &wrapperspb.StringValue{Value: runID}
 }
 
+// Updates the resume behavior of the run.
+func (s *Settings) UpdateResume(resume string) {
+	s.Proto.Resume = &wrapperspb.StringValue{Value: resume}
+}
+
 // Update server-side derived summary computation setting.
 func (s *Settings) UpdateServerSideDerivedSummary(enable bool) {
 	s.Proto.XServerSideDerivedSummary = &wrapperspb.BoolValue{Value: enable}
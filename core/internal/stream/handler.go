@@ -53,6 +53,10 @@ type HandlerParams struct {
 	SystemMonitor     *monitor.SystemMonitor
 	TBHandler         *tensorboard.TBHandler
 	TerminalPrinter   *observability.Printer
+
+	// ValidateMetricSteps enables warnings when a metric is logged
+	// at a step that's not after its previous step.
+	ValidateMetricSteps bool
 }
 
 // Handler handles the incoming messages, processes them, and passes them to the writer.
@@ -117,6 +121,11 @@ type Handler struct {
 	// settings is the settings for the handler
 	settings *settings.Settings
 
+	// stepValidator detects metrics logged at non-increasing steps.
+	//
+	// It is nil if the validation is disabled.
+	stepValidator *runhistory.StepValidator
+
 	// systemMonitor is the system monitor for the stream
 	systemMonitor *monitor.SystemMonitor
 
@@ -131,7 +140,7 @@ type Handler struct {
 func NewHandler(
 	params HandlerParams,
 ) *Handler {
-	return &Handler{
+	h := &Handler{
 		commit:               params.Commit,
 		fileTransferStats:    params.FileTransferStats,
 		fwdChan:              params.FwdChan,
@@ -141,7 +150,7 @@ func NewHandler(
 		operations:           params.Operations,
 		outChan:              params.OutChan,
 		pollExitLogRateLimit: rate.NewLimiter(rate.Every(time.Minute), 1),
-		runHistorySampler:    runhistory.NewRunHistorySampler(),
+		runHistorySampler:    runhistory.NewRunHistorySampler(runhistory.RunHistorySamplerParams{}),
 		runSummary:           runsummary.New(),
 		runTimer:             timer.New(),
 		settings:             params.Settings,
@@ -149,6 +158,12 @@ func NewHandler(
 		tbHandler:            params.TBHandler,
 		terminalPrinter:      params.TerminalPrinter,
 	}
+
+	if params.ValidateMetricSteps {
+		h.stepValidator = runhistory.NewStepValidator()
+	}
+
+	return h
 }
 
 // Do processes all work on the input channel.
@@ -997,6 +1012,8 @@ func (h *Handler) handlePartialHistorySync(request *spb.PartialHistoryRequest) {
 		}
 	}
 
+	h.validateMetricSteps(request.GetItem())
+
 	for _, item := range request.GetItem() {
 		err := h.partialHistory.SetFromRecord(item)
 		if err != nil {
@@ -1020,6 +1037,39 @@ func (h *Handler) handlePartialHistorySync(request *spb.PartialHistoryRequest) {
 	h.flushPartialHistory(true, h.partialHistoryStep+1)
 }
 
+// validateMetricSteps warns about metrics logged at a step that's not
+// after the step at which they were last logged.
+//
+// The items are assumed to be logged at the current partialHistoryStep.
+// This does nothing if step validation is disabled.
+func (h *Handler) validateMetricSteps(items []*spb.HistoryItem) {
+	if h.stepValidator == nil {
+		return
+	}
+
+	for _, item := range items {
+		var path pathtree.TreePath
+		switch {
+		case len(item.GetNestedKey()) > 0:
+			path = pathtree.PathOf(item.NestedKey[0], item.NestedKey[1:]...)
+		case len(item.GetKey()) > 0:
+			path = pathtree.PathOf(item.Key)
+		default:
+			continue
+		}
+
+		warning := h.stepValidator.Observe(path, h.partialHistoryStep)
+		if warning == nil {
+			continue
+		}
+
+		h.logger.Warn("handler: non-increasing metric step", "warning", warning)
+		h.terminalPrinter.
+			AtMostEvery(time.Minute).
+			Writef("Data may be overwritten or out of order: %v.", warning)
+	}
+}
+
 // flushPartialHistory finalizes and resets the accumulated run history.
 //
 // If useStep is true, then the emitted history record has an explicit
@@ -976,3 +976,41 @@ func TestHandleDerivedSummary(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlePartialHistory_ValidateMetricSteps(t *testing.T) {
+	testCases := []struct {
+		name             string
+		validate         bool
+		expectedMessages int
+	}{
+		{"enabled", true, 1},
+		{"disabled", false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inChan := make(chan runwork.Work, stream.BufferSize)
+			fwdChan := make(chan runwork.Work, stream.BufferSize)
+			printer := observability.NewPrinter()
+			h := stream.NewHandler(stream.HandlerParams{
+				Logger:              observability.NewNoOpLogger(),
+				Settings:            settings.New(),
+				FwdChan:             fwdChan,
+				OutChan:             make(chan *spb.Result, stream.BufferSize),
+				TerminalPrinter:     printer,
+				ValidateMetricSteps: tc.validate,
+			})
+			go h.Do(inChan)
+
+			for _, d := range []data{
+				{items: map[string]string{"x": "1"}, step: 0},
+				{items: map[string]string{"x": "2"}, step: 0, flush: true},
+			} {
+				inChan <- runwork.WorkRecord{Record: makePartialHistoryRecord(d)}
+			}
+			<-fwdChan // wait for the history record
+
+			assert.Len(t, printer.Read(), tc.expectedMessages)
+		})
+	}
+}
@@ -16,6 +16,12 @@ type ReaderParams struct {
 	Logger   *observability.CoreLogger
 	Settings *settings.Settings
 	RunWork  runwork.RunWork
+
+	// SyncProgress is how much of the transaction log was already synced.
+	//
+	// If set, records that were synced by a previous, interrupted sync and
+	// that would be duplicated on the server are not replayed.
+	SyncProgress *SyncProgress
 }
 
 // Reader is responsible for reading records from a transaction log file
@@ -29,13 +35,17 @@ type Reader struct {
 
 	// runWork is the run work object that will be used to dispatch records
 	runWork runwork.RunWork
+
+	// syncProgress is the progress of previous syncs, or nil
+	syncProgress *SyncProgress
 }
 
 func NewReader(params ReaderParams) *Reader {
 	return &Reader{
-		settings: params.Settings,
-		logger:   params.Logger,
-		runWork:  params.RunWork,
+		settings:     params.Settings,
+		logger:       params.Logger,
+		runWork:      params.RunWork,
+		syncProgress: params.SyncProgress,
 	}
 }
 
@@ -53,12 +63,26 @@ func (r *Reader) Do() {
 		return
 	}
 
+	var lastSyncedNum int64
+	if r.syncProgress != nil {
+		lastSyncedNum = r.syncProgress.LastRecordNum()
+	}
+
+	// Continue the run on the server rather than starting over, so that
+	// the records we skip below line up with what was already uploaded.
+	if lastSyncedNum > 0 && r.settings.GetResume() == "" {
+		r.logger.Info(
+			"reader: Do: resuming partial sync",
+			"last_synced_record", lastSyncedNum,
+		)
+		r.settings.UpdateResume("allow")
+	}
+
 	// Infinite loop to read records from the store.
 	// This loop will continue until an error occurs or the reader reaches the
 	// end of the file.
 	//
-	// TODO: add logic to track and report progress in the store, as well as
-	//       to handle the case where the store doesn't have an exit record.
+	// TODO: handle the case where the store doesn't have an exit record.
 	for {
 		record, err := r.store.Read()
 		if err == io.EOF {
@@ -69,6 +93,10 @@ func (r *Reader) Do() {
 			r.logger.CaptureError(err)
 			return
 		}
+		if record.GetNum() <= lastSyncedNum && !isResendable(record) {
+			continue
+		}
+
 		switch record.RecordType.(type) {
 		case *spb.Record_Run:
 			// Handle Run records.
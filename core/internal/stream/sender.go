@@ -36,6 +36,7 @@ import (
 	"github.com/wandb/wandb/core/internal/settings"
 	"github.com/wandb/wandb/core/internal/tensorboard"
 	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/internal/waiting"
 	"github.com/wandb/wandb/core/internal/watcher"
 	"github.com/wandb/wandb/core/internal/wboperation"
 	"github.com/wandb/wandb/core/pkg/artifacts"
@@ -70,6 +71,14 @@ type SenderParams struct {
 	Mailbox             *mailbox.Mailbox
 	OutChan             chan *spb.Result
 	RunWork             runwork.RunWork
+
+	// Clock is used to detect slow operations. If nil, the real clock
+	// is used.
+	Clock waiting.Clock
+
+	// SyncProgress, if set, is updated as records from the transaction log
+	// are confirmed to be uploaded. It is only used when syncing.
+	SyncProgress *SyncProgress
 }
 
 // senderSentinel is used when flushing buffered work while finalizing a run.
@@ -110,6 +119,9 @@ type Sender struct {
 	// logger is the logger for the sender
 	logger *observability.CoreLogger
 
+	// clock is used to detect slow operations
+	clock waiting.Clock
+
 	operations *wboperation.WandbOperations
 
 	// settings is the settings for the sender
@@ -190,6 +202,25 @@ type Sender struct {
 
 	// consoleLogsSender uploads captured console output.
 	consoleLogsSender *runconsolelogs.Sender
+
+	// syncProgress records which synced records reached the server, or nil.
+	syncProgress *SyncProgress
+
+	// lastRecordNum is the Num of the last transaction log record processed.
+	lastRecordNum int64
+
+	// pendingSyncCheckpoint is a record number that will be saved to
+	// syncProgress after the next successful FileStream request.
+	pendingSyncCheckpoint *syncCheckpoint
+}
+
+// syncCheckpoint is a candidate for saving to a SyncProgress.
+type syncCheckpoint struct {
+	// recordNum is the Num of the last record sent to FileStream.
+	recordNum int64
+
+	// createdAt is when FileStream was found to have no buffered data.
+	createdAt time.Time
 }
 
 // NewSender creates a new Sender with the given settings
@@ -287,6 +318,7 @@ func NewSender(
 			params.FeatureProvider.GetFeature(
 				spb.ServerFeature_USE_ARTIFACT_WITH_ENTITY_AND_PROJECT_INFORMATION,
 			).Enabled,
+			0, // use the default multipart chunk size
 		),
 		tbHandler:     params.TBHandler,
 		networkPeeker: params.Peeker,
@@ -309,6 +341,12 @@ func NewSender(
 
 	s.sentinelCond = sync.NewCond(&s.mu)
 
+	s.syncProgress = params.SyncProgress
+	s.clock = params.Clock
+	if s.clock == nil {
+		s.clock = waiting.NewRealClock()
+	}
+
 	backendOrNil := params.Backend
 	if !s.settings.IsOffline() && backendOrNil != nil && !s.settings.IsJobCreationDisabled() {
 		s.jobBuilder = launch.NewJobBuilder(s.settings.Proto, s.logger, false)
@@ -338,6 +376,7 @@ func (s *Sender) Do(allWork <-chan runwork.Work) {
 		s.mu.Lock()
 		work.Process(s.sendRecord)
 		s.observeSentinel(work)
+		s.updateSyncProgress(work)
 
 		// TODO: reevaluate the logic here
 		s.configDebouncer.Debounce(s.upsertConfig)
@@ -363,26 +402,30 @@ func (s *Sender) warnOnLongOperations(
 ) {
 outerLoop:
 	for work := range hangDetectionInChan {
-		start := time.Now()
+		start := s.clock.Now()
 
 		for i := 0; ; i++ {
+			slowWarning := s.clock.NewTimer(10 * time.Minute)
+
 			select {
 			case <-hangDetectionOutChan:
+				slowWarning.Stop()
+
 				if i > 0 {
 					s.logger.CaptureInfo(
 						"sender: succeeded after taking longer than expected",
-						"seconds", time.Since(start).Seconds(),
+						"seconds", s.clock.Now().Sub(start).Seconds(),
 						"work", work.DebugInfo(),
 					)
 				}
 
 				continue outerLoop
 
-			case <-time.After(10 * time.Minute):
+			case <-slowWarning.C():
 				if i < 6 {
 					s.logger.CaptureWarn(
 						"sender: taking a long time",
-						"seconds", time.Since(start).Seconds(),
+						"seconds", s.clock.Now().Sub(start).Seconds(),
 						"work", work.DebugInfo(),
 					)
 				}
@@ -726,6 +769,11 @@ func (s *Sender) finishRunSync() {
 		runStage(s.finishFileStream)
 	}
 
+	// Everything read from the transaction log has been uploaded.
+	if s.syncProgress != nil {
+		s.saveSyncProgress(s.lastRecordNum)
+	}
+
 	// From this point on, no new work may be generated by this function.
 
 	// Indicate that `run.finish()` is done.
@@ -813,6 +861,53 @@ func (s *Sender) observeSentinel(work runwork.Work) {
 	}
 }
 
+// updateSyncProgress checkpoints the sync after FileStream catches up.
+//
+// FileStream uploads asynchronously, so a record being processed does not
+// mean it reached the server. Once FileStream has nothing buffered, all
+// processed records are included in its next request; when that request
+// succeeds, they are saved as synced.
+func (s *Sender) updateSyncProgress(work runwork.Work) {
+	if s.syncProgress == nil {
+		return
+	}
+
+	work.Process(func(record *spb.Record) {
+		if record.GetNum() > s.lastRecordNum {
+			s.lastRecordNum = record.GetNum()
+		}
+	})
+
+	if s.fileStream == nil {
+		return
+	}
+
+	stats := s.fileStream.Stats()
+
+	if s.pendingSyncCheckpoint != nil &&
+		stats.LastSuccessfulFlush.After(s.pendingSyncCheckpoint.createdAt) {
+		s.saveSyncProgress(s.pendingSyncCheckpoint.recordNum)
+		s.pendingSyncCheckpoint = nil
+	}
+
+	if s.pendingSyncCheckpoint == nil &&
+		s.lastRecordNum > s.syncProgress.LastRecordNum() &&
+		stats.QueuedUpdates == 0 &&
+		stats.BufferedLines == 0 {
+		s.pendingSyncCheckpoint = &syncCheckpoint{
+			recordNum: s.lastRecordNum,
+			createdAt: s.clock.Now(),
+		}
+	}
+}
+
+// saveSyncProgress records that all records up to recordNum were synced.
+func (s *Sender) saveSyncProgress(recordNum int64) {
+	if err := s.syncProgress.Save(recordNum); err != nil {
+		s.logger.CaptureError(fmt.Errorf("sender: %v", err))
+	}
+}
+
 // finishFileStream waits for FileStream uploads to complete.
 func (s *Sender) finishFileStream() {
 	switch {
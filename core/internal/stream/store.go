@@ -165,6 +165,20 @@ func (sr *Store) Write(msg *spb.Record) error {
 	return nil
 }
 
+// Sync flushes buffered records and commits them to stable storage.
+//
+// Records written before a successful Sync survive a crash of the process
+// or the machine.
+func (sr *Store) Sync() error {
+	if err := sr.writer.Flush(); err != nil {
+		return fmt.Errorf("store: failed to flush: %v", err)
+	}
+	if err := sr.db.Sync(); err != nil {
+		return fmt.Errorf("store: failed to sync: %v", err)
+	}
+	return nil
+}
+
 // Reads the next record from the database.
 //
 // Returns nil and an error on failure. On EOF, error is [io.EOF].
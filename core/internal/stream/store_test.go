@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -282,3 +283,25 @@ func TestReadFromClosedStore(t *testing.T) {
 	_, err = store.Read()
 	assert.Error(t, err, "can't read record")
 }
+
+func TestSyncMakesRecordsReadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	store := stream.NewStore(path)
+	assert.NoError(t, store.Open(os.O_WRONLY))
+	defer func() {
+		_ = store.Close()
+	}()
+
+	assert.NoError(t, store.Write(&spb.Record{Num: 1, Uuid: "test-uuid"}))
+	assert.NoError(t, store.Sync())
+
+	// The record is readable before the writer is closed.
+	store2 := stream.NewStore(path)
+	assert.NoError(t, store2.Open(os.O_RDONLY))
+	defer func() {
+		_ = store2.Close()
+	}()
+	record, err := store2.Read()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, record.Num)
+}
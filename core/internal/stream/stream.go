@@ -12,6 +12,7 @@ import (
 	"github.com/wandb/wandb/core/internal/featurechecker"
 	"github.com/wandb/wandb/core/internal/filestream"
 	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/gqlhooks"
 	"github.com/wandb/wandb/core/internal/mailbox"
 	"github.com/wandb/wandb/core/internal/observability"
 	"github.com/wandb/wandb/core/internal/pfxout"
@@ -23,6 +24,7 @@ import (
 	"github.com/wandb/wandb/core/internal/settings"
 	"github.com/wandb/wandb/core/internal/tensorboard"
 	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/internal/waiting"
 	"github.com/wandb/wandb/core/internal/watcher"
 	"github.com/wandb/wandb/core/internal/wboperation"
 	"github.com/wandb/wandb/core/pkg/monitor"
@@ -145,6 +147,17 @@ type StreamParams struct {
 	LogLevel   slog.Level
 
 	GPUResourceManager *monitor.GPUResourceManager
+
+	// ValidateMetricSteps enables warnings for metrics logged
+	// at non-increasing steps.
+	ValidateMetricSteps bool
+
+	// GraphQLHooks, if set, is notified about GraphQL requests.
+	GraphQLHooks gqlhooks.Hooks
+
+	// Clock is used to detect slow operations. If nil, the real clock
+	// is used.
+	Clock waiting.Clock
 }
 
 // NewStream creates a new stream with the given settings and responders.
@@ -159,8 +172,13 @@ func NewStream(
 		params.LoggerPath,
 		params.LogLevel,
 	)
+	clock := params.Clock
+	if clock == nil {
+		clock = waiting.NewRealClock()
+	}
+
 	s := &Stream{
-		runWork:      runwork.New(BufferSize, logger),
+		runWork:      runwork.New(BufferSize, logger, runwork.WithClock(clock)),
 		logger:       logger,
 		settings:     params.Settings,
 		sentryClient: params.Sentry,
@@ -190,6 +208,12 @@ func NewStream(
 			peeker,
 			clientId,
 		)
+		if params.GraphQLHooks != nil {
+			graphqlClientOrNil = gqlhooks.NewClient(
+				graphqlClientOrNil,
+				params.GraphQLHooks,
+			)
+		}
 		fileStreamOrNil = NewFileStream(
 			backendOrNil,
 			s.logger,
@@ -223,11 +247,19 @@ func NewStream(
 	)
 
 	mailbox := mailbox.New()
+	var syncProgress *SyncProgress
 	if s.settings.IsSync() {
+		var err error
+		syncProgress, err = LoadSyncProgress(s.settings.GetTransactionLogPath())
+		if err != nil {
+			s.logger.CaptureError(err)
+		}
+
 		s.reader = NewReader(ReaderParams{
-			Logger:   s.logger,
-			Settings: s.settings,
-			RunWork:  s.runWork,
+			Logger:       s.logger,
+			Settings:     s.settings,
+			RunWork:      s.runWork,
+			SyncProgress: syncProgress,
 		})
 	} else {
 		s.writer = NewWriter(WriterParams{
@@ -253,8 +285,9 @@ func NewStream(
 				s.runWork,
 				params.GPUResourceManager,
 			),
-			TBHandler:       tbHandler,
-			TerminalPrinter: terminalPrinter,
+			TBHandler:           tbHandler,
+			TerminalPrinter:     terminalPrinter,
+			ValidateMetricSteps: params.ValidateMetricSteps,
 		},
 	)
 
@@ -277,6 +310,8 @@ func NewStream(
 			Mailbox:             mailbox,
 			RunWork:             s.runWork,
 			FeatureProvider:     featureProvider,
+			Clock:               clock,
+			SyncProgress:        syncProgress,
 		},
 	)
 
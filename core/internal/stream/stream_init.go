@@ -23,7 +23,6 @@ import (
 	"github.com/wandb/wandb/core/internal/waiting"
 	"github.com/wandb/wandb/core/internal/watcher"
 	"github.com/wandb/wandb/core/internal/wboperation"
-	"golang.org/x/time/rate"
 )
 
 // NewBackend returns a Backend or nil if we're offline.
@@ -214,10 +213,8 @@ func NewFileStream(
 		Operations: operations,
 		Printer:    printer,
 		ApiClient:  fileStreamRetryClient,
-	}
 
-	if txInterval := settings.GetFileStreamTransmitInterval(); txInterval > 0 {
-		params.TransmitRateLimit = rate.NewLimiter(rate.Every(txInterval), 1)
+		FlushInterval: settings.GetFileStreamTransmitInterval(),
 	}
 
 	return filestream.NewFileStream(params)
@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/wandb/wandb/core/internal/runmetadata"
+)
+
+// StreamRun holds the run associated to a stream, once it's created.
+//
+// It implements runmetadata.StreamRunMetadata.
+type StreamRun struct {
+	mu sync.Mutex
+
+	// metadata is the run's metadata, or nil if there's no run yet.
+	metadata *runmetadata.RunMetadata
+
+	// runSet is closed when a run is set.
+	//
+	// It is replaced by a new channel when the run is reset.
+	runSet chan struct{}
+}
+
+func NewStreamRun() *StreamRun {
+	return &StreamRun{runSet: make(chan struct{})}
+}
+
+// SetRun implements runmetadata.StreamRunMetadata.SetRun.
+func (sr *StreamRun) SetRun(metadata *runmetadata.RunMetadata) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if sr.metadata != nil {
+		return errors.New("stream: run already set")
+	}
+
+	sr.metadata = metadata
+	close(sr.runSet)
+	return nil
+}
+
+// Metadata implements runmetadata.StreamRunMetadata.Metadata.
+func (sr *StreamRun) Metadata() (*runmetadata.RunMetadata, error) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if sr.metadata == nil {
+		return nil, errors.New("stream: no run")
+	}
+
+	return sr.metadata, nil
+}
+
+// ResetRun clears the stream's run so that a new one can be set.
+//
+// This allows reusing a stream for a new run after the previous one
+// finishes. It is an error to reset a run that's not finished, since
+// its metadata may still be uploading. Resetting when there is no run
+// is a no-op.
+func (sr *StreamRun) ResetRun() error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if sr.metadata == nil {
+		return nil
+	}
+
+	if !sr.metadata.IsFinished() {
+		return errors.New("stream: cannot reset run that is still active")
+	}
+
+	sr.metadata = nil
+	sr.runSet = make(chan struct{})
+	return nil
+}
+
+// MetadataSnapshot returns a copy of the run's identifying information.
+//
+// Unlike Metadata, the result is safe to read from any goroutine.
+func (sr *StreamRun) MetadataSnapshot() (runmetadata.Snapshot, error) {
+	metadata, err := sr.Metadata()
+	if err != nil {
+		return runmetadata.Snapshot{}, err
+	}
+
+	return metadata.Snapshot(), nil
+}
+
+// WaitForRun blocks until a run is set and returns its metadata.
+//
+// Returns immediately if a run is already set. Returns the context's error
+// if it is cancelled first.
+func (sr *StreamRun) WaitForRun(
+	ctx context.Context,
+) (*runmetadata.RunMetadata, error) {
+	for {
+		sr.mu.Lock()
+		metadata := sr.metadata
+		runSet := sr.runSet
+		sr.mu.Unlock()
+
+		if metadata != nil {
+			return metadata, nil
+		}
+
+		select {
+		case <-runSet:
+			// Loop to read the metadata, in case the run was reset
+			// in the meantime.
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
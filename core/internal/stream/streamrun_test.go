@@ -0,0 +1,153 @@
+package stream_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wandb/wandb/core/internal/featurechecker"
+	"github.com/wandb/wandb/core/internal/observability"
+	"github.com/wandb/wandb/core/internal/runmetadata"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/stream"
+	"github.com/wandb/wandb/core/internal/waiting"
+	spb "github.com/wandb/wandb/core/pkg/service_go_proto"
+)
+
+// offlineRunMetadata returns a RunMetadata for an offline run.
+func offlineRunMetadata(t *testing.T, runID string) *runmetadata.RunMetadata {
+	t.Helper()
+
+	metadata, err := runmetadata.InitRun(
+		&spb.Record{
+			RecordType: &spb.Record_Run{
+				Run: &spb.RunRecord{RunId: runID},
+			},
+		},
+		runmetadata.RunMetadataParams{
+			DebounceDelay:   waiting.NoDelay(),
+			Settings:        settings.New(),
+			BeforeRunEndCtx: context.Background(),
+			FeatureProvider: featurechecker.NewServerFeaturesCachePreloaded(
+				map[spb.ServerFeature]featurechecker.Feature{},
+			),
+			Logger: observability.NewNoOpLogger(),
+		},
+	)
+	require.NoError(t, err)
+
+	return metadata
+}
+
+func TestStreamRun_SetRunTwice_Fails(t *testing.T) {
+	sr := stream.NewStreamRun()
+
+	require.NoError(t, sr.SetRun(offlineRunMetadata(t, "run1")))
+	err := sr.SetRun(offlineRunMetadata(t, "run2"))
+
+	assert.ErrorContains(t, err, "run already set")
+}
+
+func TestStreamRun_ResetRun_NoRun_IsNoOp(t *testing.T) {
+	sr := stream.NewStreamRun()
+
+	assert.NoError(t, sr.ResetRun())
+}
+
+func TestStreamRun_ResetRun_ActiveRun_Fails(t *testing.T) {
+	sr := stream.NewStreamRun()
+	metadata := offlineRunMetadata(t, "run1")
+	require.NoError(t, sr.SetRun(metadata))
+
+	err := sr.ResetRun()
+	current, _ := sr.Metadata()
+
+	assert.ErrorContains(t, err, "still active")
+	assert.Same(t, metadata, current)
+}
+
+func TestStreamRun_ResetRun_FinishedRun_AllowsNewRun(t *testing.T) {
+	sr := stream.NewStreamRun()
+	metadata1 := offlineRunMetadata(t, "run1")
+	metadata2 := offlineRunMetadata(t, "run2")
+	require.NoError(t, sr.SetRun(metadata1))
+	metadata1.Finish()
+
+	require.NoError(t, sr.ResetRun())
+	_, errAfterReset := sr.Metadata()
+	require.NoError(t, sr.SetRun(metadata2))
+	current, _ := sr.Metadata()
+
+	assert.ErrorContains(t, errAfterReset, "no run")
+	assert.Same(t, metadata2, current)
+}
+
+func TestStreamRun_MetadataSnapshot(t *testing.T) {
+	sr := stream.NewStreamRun()
+
+	_, errBefore := sr.MetadataSnapshot()
+	require.NoError(t, sr.SetRun(offlineRunMetadata(t, "run1")))
+	snapshot, errAfter := sr.MetadataSnapshot()
+
+	assert.ErrorContains(t, errBefore, "no run")
+	assert.NoError(t, errAfter)
+	assert.Equal(t, "run1", snapshot.RunID)
+}
+
+func TestStreamRun_WaitForRun_AlreadySet(t *testing.T) {
+	sr := stream.NewStreamRun()
+	metadata := offlineRunMetadata(t, "run1")
+	require.NoError(t, sr.SetRun(metadata))
+
+	result, err := sr.WaitForRun(context.Background())
+
+	assert.NoError(t, err)
+	assert.Same(t, metadata, result)
+}
+
+func TestStreamRun_WaitForRun_BlocksUntilSet(t *testing.T) {
+	sr := stream.NewStreamRun()
+	metadata := offlineRunMetadata(t, "run1")
+	type waitResult struct {
+		metadata *runmetadata.RunMetadata
+		err      error
+	}
+	resultChan := make(chan waitResult)
+
+	go func() {
+		result, err := sr.WaitForRun(context.Background())
+		resultChan <- waitResult{result, err}
+	}()
+	require.NoError(t, sr.SetRun(metadata))
+	result := <-resultChan
+
+	assert.NoError(t, result.err)
+	assert.Same(t, metadata, result.metadata)
+}
+
+func TestStreamRun_WaitForRun_Cancelled(t *testing.T) {
+	sr := stream.NewStreamRun()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := sr.WaitForRun(ctx)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestStreamRun_WaitForRun_AfterReset(t *testing.T) {
+	sr := stream.NewStreamRun()
+	metadata1 := offlineRunMetadata(t, "run1")
+	require.NoError(t, sr.SetRun(metadata1))
+	metadata1.Finish()
+	require.NoError(t, sr.ResetRun())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := sr.WaitForRun(ctx)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.Canceled)
+}
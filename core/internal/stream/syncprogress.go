@@ -0,0 +1,128 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	spb "github.com/wandb/wandb/core/pkg/service_go_proto"
+)
+
+// SyncProgressSuffix is appended to a transaction log's path to get the path
+// of the file recording how much of it has been synced.
+const SyncProgressSuffix = ".synced"
+
+// SyncProgress records how far a transaction log has been synced.
+//
+// It lets a sync that was interrupted resume without re-uploading history,
+// system metrics and console output that already reached the server.
+type SyncProgress struct {
+	mu sync.Mutex
+
+	// path is the file where progress is persisted.
+	path string
+
+	// lastRecordNum is the Num of the last record known to be synced.
+	lastRecordNum int64
+}
+
+type syncProgressJSON struct {
+	LastRecordNum int64 `json:"last_record_num"`
+}
+
+// LoadSyncProgress reads the sync progress of the given transaction log.
+//
+// If the log has never been synced, the returned progress is empty.
+func LoadSyncProgress(transactionLogPath string) (*SyncProgress, error) {
+	progress := &SyncProgress{path: transactionLogPath + SyncProgressSuffix}
+
+	data, err := os.ReadFile(progress.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return progress, nil
+	case err != nil:
+		return progress, fmt.Errorf("stream: failed to read sync progress: %v", err)
+	}
+
+	var saved syncProgressJSON
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return progress, fmt.Errorf("stream: failed to parse sync progress: %v", err)
+	}
+
+	progress.lastRecordNum = saved.LastRecordNum
+	return progress, nil
+}
+
+// LastRecordNum returns the Num of the last record known to be synced.
+//
+// It is zero if nothing has been synced.
+func (p *SyncProgress) LastRecordNum() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastRecordNum
+}
+
+// Save durably records that all records up to recordNum have been synced.
+//
+// Progress never moves backward: saving a smaller number is a no-op.
+func (p *SyncProgress) Save(recordNum int64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if recordNum <= p.lastRecordNum {
+		return nil
+	}
+
+	data, err := json.Marshal(syncProgressJSON{LastRecordNum: recordNum})
+	if err != nil {
+		return fmt.Errorf("stream: failed to marshal sync progress: %v", err)
+	}
+
+	// Write to a temporary file and rename it so that a crash never leaves
+	// behind a partially written progress file.
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), filepath.Base(p.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("stream: failed to save sync progress: %v", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), p.path)
+	}
+	if err != nil {
+		return fmt.Errorf("stream: failed to save sync progress: %v", err)
+	}
+
+	p.lastRecordNum = recordNum
+	return nil
+}
+
+// isResendable reports whether replaying the record after it was already
+// synced is harmless.
+//
+// Records that append data on the server, such as history rows and console
+// lines, would be duplicated. Records that set state, such as the config,
+// summary or run metadata, are idempotent and are always replayed so that
+// the sender can rebuild its state.
+func isResendable(record *spb.Record) bool {
+	switch record.RecordType.(type) {
+	case *spb.Record_History,
+		*spb.Record_Stats,
+		*spb.Record_Output,
+		*spb.Record_OutputRaw,
+		*spb.Record_Alert:
+		return false
+	default:
+		return true
+	}
+}
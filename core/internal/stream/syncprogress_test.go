@@ -0,0 +1,83 @@
+package stream_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wandb/wandb/core/internal/observability"
+	"github.com/wandb/wandb/core/internal/runwork"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/stream"
+	spb "github.com/wandb/wandb/core/pkg/service_go_proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSyncProgress_EmptyIfNeverSynced(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+
+	progress, err := stream.LoadSyncProgress(path)
+
+	require.NoError(t, err)
+	assert.Zero(t, progress.LastRecordNum())
+}
+
+func TestSyncProgress_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	progress, err := stream.LoadSyncProgress(path)
+	require.NoError(t, err)
+
+	require.NoError(t, progress.Save(7))
+	require.NoError(t, progress.Save(3)) // ignored: progress never regresses
+
+	reloaded, err := stream.LoadSyncProgress(path)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, progress.LastRecordNum())
+	assert.EqualValues(t, 7, reloaded.LastRecordNum())
+}
+
+func TestReader_SkipsAlreadySyncedRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	store := stream.NewStore(path)
+	require.NoError(t, store.Open(os.O_WRONLY))
+	records := []*spb.Record{
+		{Num: 1, RecordType: &spb.Record_Run{Run: &spb.RunRecord{RunId: "run"}}},
+		{Num: 2, RecordType: &spb.Record_History{History: &spb.HistoryRecord{}}},
+		{Num: 3, RecordType: &spb.Record_Config{Config: &spb.ConfigRecord{}}},
+		{Num: 4, RecordType: &spb.Record_History{History: &spb.HistoryRecord{}}},
+	}
+	for _, record := range records {
+		require.NoError(t, store.Write(record))
+	}
+	require.NoError(t, store.Close())
+	progress, err := stream.LoadSyncProgress(path)
+	require.NoError(t, err)
+	require.NoError(t, progress.Save(3))
+	s := settings.From(&spb.Settings{
+		XSync:    wrapperspb.Bool(true),
+		SyncFile: wrapperspb.String(path),
+	})
+	rw := runwork.New(16, observability.NewNoOpLogger())
+
+	stream.NewReader(stream.ReaderParams{
+		Logger:       observability.NewNoOpLogger(),
+		Settings:     s,
+		RunWork:      rw,
+		SyncProgress: progress,
+	}).Do()
+	rw.SetDone()
+	rw.Close()
+
+	var nums []int64
+	for work := range rw.Chan() {
+		work.Process(func(record *spb.Record) {
+			if record.GetRequest() == nil {
+				nums = append(nums, record.GetNum())
+			}
+		})
+	}
+	assert.Equal(t, []int64{1, 3, 4}, nums)
+	assert.Equal(t, "allow", s.GetResume())
+}
@@ -80,6 +80,21 @@ func (w *Writer) startStore() {
 						"writer: startStore: error storing record: %v",
 						err,
 					))
+				continue
+			}
+
+			// Sync at the end of each batch of records and after records
+			// that mark a boundary in the run's lifecycle, so that a crash
+			// loses at most the records that were still being written.
+			if len(w.storeChan) > 0 && !isSyncBoundary(record) {
+				continue
+			}
+			if err = w.store.Sync(); err != nil {
+				w.logger.CaptureError(
+					fmt.Errorf(
+						"writer: startStore: error syncing store: %v",
+						err,
+					))
 			}
 		}
 
@@ -161,3 +176,14 @@ func (w *Writer) storeRecord(record *spb.Record) {
 	}
 	w.storeChan <- record
 }
+
+// isSyncBoundary reports whether the record must be committed to disk
+// as soon as it is stored.
+func isSyncBoundary(record *spb.Record) bool {
+	switch record.RecordType.(type) {
+	case *spb.Record_Run, *spb.Record_Exit, *spb.Record_Final:
+		return true
+	default:
+		return false
+	}
+}
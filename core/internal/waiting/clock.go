@@ -0,0 +1,72 @@
+package waiting
+
+import "time"
+
+// Clock tells the time and creates timers.
+//
+// Code that sleeps or measures durations should use a Clock instead of
+// the `time` package so that tests can control the passage of time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel that receives the current time once
+	// the duration elapses.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer creates a Timer that fires after the duration elapses.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event that happens at some point in the future.
+//
+// It is a subset of the methods of time.Timer.
+type Timer interface {
+	// C returns the channel on which the timer's time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing.
+	//
+	// It returns false if the timer already fired or was stopped.
+	Stop() bool
+
+	// Reset changes the timer to fire after the duration elapses.
+	//
+	// It returns false if the timer had already fired or been stopped.
+	Reset(d time.Duration) bool
+}
+
+// NewRealClock returns a Clock backed by the `time` package.
+func NewRealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t *realTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
@@ -0,0 +1,157 @@
+package waitingtest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/waiting"
+)
+
+// FakeClock is a Clock whose time only changes when Advance is called.
+//
+// Timers and After channels fire once the clock is advanced past their
+// deadlines, which lets tests fast-forward through long waits.
+type FakeClock struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Advance moves the clock forward, firing any timers that expire.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	c.timers = slices.DeleteFunc(c.timers, func(timer *fakeTimer) bool {
+		if timer.deadline.After(c.now) {
+			return false
+		}
+
+		// The channel has a buffer of 1 and is drained by Reset,
+		// so this never blocks.
+		select {
+		case timer.c <- c.now:
+		default:
+		}
+		return true
+	})
+}
+
+// WaitForTimers blocks until at least n timers are pending.
+//
+// Use this to make sure the code under test started waiting before
+// calling Advance. This fails the test after a timeout.
+func (c *FakeClock) WaitForTimers(t *testing.T, n int, timeout time.Duration) {
+	t.Helper()
+
+	success := make(chan struct{})
+	go func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		for len(c.timers) < n {
+			c.cond.Wait()
+		}
+		close(success)
+	}()
+
+	select {
+	case <-success:
+	case <-time.After(timeout):
+		t.Fatalf("fewer than %d timers after %v in WaitForTimers()", n, timeout)
+	}
+}
+
+// Prove we implement the Clock interface.
+var _ waiting.Clock = &FakeClock{}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) waiting.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	c.lockedSchedule(timer, d)
+	return timer
+}
+
+// lockedSchedule makes the timer fire after the duration.
+//
+// The mutex must be held.
+func (c *FakeClock) lockedSchedule(timer *fakeTimer, d time.Duration) {
+	timer.deadline = c.now.Add(d)
+
+	if d <= 0 {
+		timer.c <- c.now
+		return
+	}
+
+	c.timers = append(c.timers, timer)
+	c.cond.Broadcast()
+}
+
+// lockedUnschedule removes the timer, returning whether it was pending.
+//
+// The mutex must be held.
+func (c *FakeClock) lockedUnschedule(timer *fakeTimer) bool {
+	idx := slices.Index(c.timers, timer)
+	if idx < 0 {
+		return false
+	}
+
+	c.timers = slices.Delete(c.timers, idx, idx+1)
+	return true
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.lockedUnschedule(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasPending := t.clock.lockedUnschedule(t)
+
+	// Like time.Timer since Go 1.23, drop any stale value.
+	select {
+	case <-t.c:
+	default:
+	}
+
+	t.clock.lockedSchedule(t, d)
+	return wasPending
+}
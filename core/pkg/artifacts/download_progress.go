@@ -0,0 +1,106 @@
+package artifacts
+
+import "sync"
+
+// DownloadProgress describes the progress of an artifact download.
+type DownloadProgress struct {
+	// Path is the file that made progress, relative to the download root.
+	Path string
+
+	// FileBytesDone is the number of bytes of the file downloaded so far.
+	FileBytesDone int64
+
+	// FileBytesTotal is the size of the file.
+	FileBytesTotal int64
+
+	// BytesDone is the number of bytes of the artifact downloaded so far.
+	//
+	// Files restored from the cache count as downloaded.
+	BytesDone int64
+
+	// BytesTotal is the size of the artifact.
+	BytesTotal int64
+}
+
+// downloadProgressTracker aggregates per-file progress into
+// DownloadProgress updates.
+type downloadProgressTracker struct {
+	mu sync.Mutex
+
+	// callback receives progress updates, one at a time.
+	//
+	// If nil, progress is not tracked.
+	callback func(DownloadProgress)
+
+	// fileBytesDone is the progress of files being downloaded.
+	fileBytesDone map[string]int64
+
+	// bytesDone is the total progress of all files.
+	bytesDone int64
+
+	// bytesTotal is the size of all files.
+	bytesTotal int64
+}
+
+func newDownloadProgressTracker(
+	callback func(DownloadProgress),
+	manifest Manifest,
+) *downloadProgressTracker {
+	tracker := &downloadProgressTracker{
+		callback:      callback,
+		fileBytesDone: make(map[string]int64),
+	}
+
+	for _, entry := range manifest.Contents {
+		tracker.bytesTotal += entry.Size
+	}
+
+	return tracker
+}
+
+// FileProgress records that some bytes of a file were downloaded.
+func (t *downloadProgressTracker) FileProgress(path string, done, total int64) {
+	if t.callback == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.bytesDone += done - t.fileBytesDone[path]
+	t.fileBytesDone[path] = done
+
+	t.callback(DownloadProgress{
+		Path:           path,
+		FileBytesDone:  done,
+		FileBytesTotal: total,
+		BytesDone:      t.bytesDone,
+		BytesTotal:     t.bytesTotal,
+	})
+}
+
+// FileDone records that a file was fully downloaded.
+func (t *downloadProgressTracker) FileDone(path string, size int64) {
+	if t.callback == nil {
+		return
+	}
+
+	t.FileProgress(path, size, size)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.fileBytesDone, path)
+}
+
+// FileFailed discards the progress of a file that will be retried.
+func (t *downloadProgressTracker) FileFailed(path string) {
+	if t.callback == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.bytesDone -= t.fileBytesDone[path]
+	delete(t.fileBytesDone, path)
+}
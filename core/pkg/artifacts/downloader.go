@@ -32,6 +32,16 @@ type ArtifactDownloader struct {
 	AllowMissingReferences bool   // Currently unused
 	SkipCache              bool   // Currently unused
 	PathPrefix             string // Currently unused
+
+	// MaxConcurrency limits the number of files downloaded at a time.
+	//
+	// If zero or negative, only the file transfer manager's limit applies.
+	MaxConcurrency int
+
+	// OnProgress, if set, is called as files are downloaded.
+	//
+	// Calls are never concurrent, but may happen on any goroutine.
+	OnProgress func(DownloadProgress)
 }
 
 func NewArtifactDownloader(
@@ -65,30 +75,7 @@ func NewArtifactDownloader(
 }
 
 func (ad *ArtifactDownloader) getArtifactManifest(artifactID string) (manifest Manifest, rerr error) {
-	response, err := gql.ArtifactManifest(
-		ad.Ctx,
-		ad.GraphqlClient,
-		artifactID,
-	)
-	if err != nil {
-		return Manifest{}, err
-	} else if response == nil {
-		return Manifest{}, fmt.Errorf("could not get manifest for artifact")
-	}
-	artifact := response.Artifact
-	if artifact == nil {
-		return Manifest{}, fmt.Errorf("could not access artifact")
-	}
-	artifactManifest := artifact.CurrentManifest
-	if artifactManifest == nil {
-		return Manifest{}, fmt.Errorf("could not access manifest for artifact")
-	}
-	directURL := artifactManifest.GetFile().DirectUrl
-	manifest, err = loadManifestFromURL(directURL)
-	if err != nil {
-		return Manifest{}, err
-	}
-	return manifest, nil
+	return fetchArtifactManifest(ad.Ctx, ad.GraphqlClient, artifactID)
 }
 
 // getBatchEntriesWithFileUrls fetches file urls for the entries in
@@ -256,6 +243,22 @@ func (ad *ArtifactDownloader) downloadFiles(artifactID string, manifest Manifest
 	manifestEntriesBatch := make([]ManifestEntry, 0, batchSize)
 	manifestEntriesCopy := map[string]ManifestEntry{}
 	maps.Copy(manifestEntriesCopy, manifestEntries)
+	progress := newDownloadProgressTracker(ad.OnProgress, manifest)
+
+	var downloadSlots chan struct{}
+	if ad.MaxConcurrency > 0 {
+		downloadSlots = make(chan struct{}, ad.MaxConcurrency)
+	}
+
+	// Waits for scheduled downloads after the context is cancelled.
+	//
+	// Downloads use the same context, so they finish promptly.
+	abort := func() error {
+		for ; numInProgress > 0; numInProgress-- {
+			<-taskResultsChan
+		}
+		return ad.Ctx.Err()
+	}
 
 	artifactFieldNames, err := GetGraphQLFields(ad.Ctx, ad.GraphqlClient, "Artifact")
 	if err != nil {
@@ -304,8 +307,24 @@ func (ad *ArtifactDownloader) downloadFiles(artifactID string, manifest Manifest
 					// and returns true if the file is there and has the correct hash.
 					if success := ad.FileCache.RestoreTo(entry, downloadLocalPath); success {
 						numDone++
+						progress.FileDone(*entry.LocalPath, entry.Size)
 						continue
 					}
+
+					if downloadSlots != nil {
+						select {
+						case downloadSlots <- struct{}{}:
+						case <-ad.Ctx.Done():
+							return abort()
+						}
+					}
+					onComplete := func(err error) {
+						if downloadSlots != nil {
+							<-downloadSlots
+						}
+						taskResultsChan <- TaskResult{downloadLocalPath, err, *entry.LocalPath}
+					}
+
 					if entry.Ref != nil {
 						task := &filetransfer.ReferenceArtifactDownloadTask{
 							FileKind:     filetransfer.RunFileKindArtifact,
@@ -322,9 +341,7 @@ func (ad *ArtifactDownloader) downloadFiles(artifactID string, manifest Manifest
 							}
 						}
 
-						task.OnComplete = func() {
-							taskResultsChan <- TaskResult{downloadLocalPath, task.Err, *entry.LocalPath}
-						}
+						task.OnComplete = func() { onComplete(task.Err) }
 						ad.DownloadManager.AddTask(task)
 					} else {
 						task := &filetransfer.DefaultDownloadTask{
@@ -332,11 +349,15 @@ func (ad *ArtifactDownloader) downloadFiles(artifactID string, manifest Manifest
 							Path:     downloadLocalPath,
 							Url:      *entry.DownloadURL,
 							Size:     entry.Size,
+							Digest:   entry.Digest,
+							Context:  ad.Ctx,
 						}
-
-						task.OnComplete = func() {
-							taskResultsChan <- TaskResult{downloadLocalPath, task.Err, *entry.LocalPath}
+						name := *entry.LocalPath
+						task.ProgressCallback = func(processed, total int) {
+							progress.FileProgress(name, int64(processed), int64(total))
 						}
+
+						task.OnComplete = func() { onComplete(task.Err) }
 						ad.DownloadManager.AddTask(task)
 					}
 					numInProgress++
@@ -344,9 +365,19 @@ func (ad *ArtifactDownloader) downloadFiles(artifactID string, manifest Manifest
 			}
 			// Wait for downloader to catch up. If there's nothing more to schedule, wait for all in progress tasks.
 			for numInProgress > MAX_BACKLOG || (len(manifestEntriesBatch) == 0 && numInProgress > 0) {
-				numInProgress--
-				result := <-taskResultsChan
+				var result TaskResult
+				select {
+				case result = <-taskResultsChan:
+					numInProgress--
+				case <-ad.Ctx.Done():
+					return abort()
+				}
 				if result.Err != nil {
+					progress.FileFailed(result.Name)
+					if ad.Ctx.Err() != nil {
+						return abort()
+					}
+
 					// We want to retry when the signed URL expires. However, distinguishing that error from others is not
 					// trivial. As a heuristic, we retry if the request failed more than an hour after we fetched the URL.
 					if time.Since(nameToScheduledTime[result.Name]) < 1*time.Hour {
@@ -356,6 +387,7 @@ func (ad *ArtifactDownloader) downloadFiles(artifactID string, manifest Manifest
 					continue
 				}
 				numDone++
+				progress.FileDone(result.Name, manifest.Contents[result.Name].Size)
 				digest := manifest.Contents[result.Name].Digest
 				go func() {
 					err := ad.FileCache.AddFileAndCheckDigest(result.Path, digest)
@@ -2,7 +2,9 @@ package artifacts
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/stretchr/testify/assert"
@@ -216,3 +218,99 @@ func TestDownloadLegacyQuery(t *testing.T) {
 		`DefaultDownloadTask{FileKind: 2, Path: file2, Name: , Url: url2, Size: 1}`,
 	)
 }
+
+// asyncFileTransferManager completes download tasks on separate goroutines,
+// tracking how many run at once.
+type asyncFileTransferManager struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+
+	// If true, tasks block until their context is cancelled.
+	blockUntilCancelled bool
+}
+
+func (m *asyncFileTransferManager) AddTask(t filetransfer.Task) {
+	go func() {
+		m.mu.Lock()
+		m.inFlight++
+		m.maxInFlight = max(m.maxInFlight, m.inFlight)
+		m.mu.Unlock()
+
+		if task, ok := t.(*filetransfer.DefaultDownloadTask); ok {
+			if m.blockUntilCancelled {
+				<-task.Context.Done()
+				task.SetError(task.Context.Err())
+			} else if task.ProgressCallback != nil {
+				task.ProgressCallback(int(task.Size), int(task.Size))
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		m.mu.Lock()
+		m.inFlight--
+		m.mu.Unlock()
+
+		t.Complete(nil)
+	}()
+}
+
+func (m *asyncFileTransferManager) Close() {}
+
+func stubFileURLs(mockGQL *gqlmock.MockClient) {
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("TypeFields"),
+		`{"TypeInfo": {"fields": [{"name": "files"}, {"name": "filesByManifestEntries"}]}}`,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("ArtifactFileURLsByManifestEntries"),
+		filesByManifestEntriesResult,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("ArtifactFileURLsByManifestEntries"),
+		noFilesByManifestEntriesResult,
+	)
+}
+
+func TestDownload_MaxConcurrencyAndProgress(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	stubFileURLs(mockGQL)
+	ftm := &asyncFileTransferManager{}
+	downloader := getFakeArtifactDownloader(mockGQL, ftm)
+	downloader.MaxConcurrency = 1
+	var updates []DownloadProgress
+	downloader.OnProgress = func(progress DownloadProgress) {
+		updates = append(updates, progress)
+	}
+
+	err := downloader.downloadFiles(fakeArtifactID, fakeManifest)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ftm.maxInFlight)
+	assert.NotEmpty(t, updates)
+	for _, update := range updates {
+		assert.EqualValues(t, 3, update.BytesTotal)
+		assert.EqualValues(t, 1, update.FileBytesTotal)
+	}
+	assert.EqualValues(t, 2, updates[len(updates)-1].BytesDone)
+}
+
+func TestDownload_Cancel(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	stubFileURLs(mockGQL)
+	ftm := &asyncFileTransferManager{blockUntilCancelled: true}
+	ctx, cancel := context.WithCancel(context.Background())
+	downloader := getFakeArtifactDownloader(mockGQL, ftm)
+	downloader.Ctx = ctx
+
+	result := make(chan error, 1)
+	go func() { result <- downloader.downloadFiles(fakeArtifactID, fakeManifest) }()
+	cancel()
+
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("download did not stop after cancellation")
+	}
+}
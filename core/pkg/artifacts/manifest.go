@@ -3,6 +3,7 @@ package artifacts
 import (
 	"bufio"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +11,9 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/Khan/genqlient/graphql"
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/wandb/wandb/core/internal/gql"
 	"github.com/wandb/wandb/core/internal/nullify"
 	"github.com/wandb/wandb/core/internal/observability"
 	spb "github.com/wandb/wandb/core/pkg/service_go_proto"
@@ -38,6 +41,23 @@ type ManifestEntry struct {
 	Extra           map[string]any `json:"extra,omitempty"`
 	// Added and used during download.
 	DownloadURL *string `json:"-"`
+	// Added and used during multipart upload.
+	Parts []ManifestEntryPart `json:"-"`
+}
+
+// ManifestEntryPart is a chunk of a file that is uploaded in parts.
+type ManifestEntryPart struct {
+	// PartNumber is the 1-based index of the part.
+	PartNumber int64
+
+	// Offset is the position of the part in the file.
+	Offset int64
+
+	// Size is the number of bytes in the part.
+	Size int64
+
+	// HexMD5 is the hex-encoded MD5 checksum of the part.
+	HexMD5 string
 }
 
 func NewManifestFromProto(proto *spb.ArtifactManifest) (Manifest, error) {
@@ -183,6 +203,38 @@ func (m *Manifest) GetManifestEntryFromArtifactFilePath(path string) (ManifestEn
 	return manifestEntry, nil
 }
 
+// fetchArtifactManifest downloads the current manifest of an artifact.
+func fetchArtifactManifest(
+	ctx context.Context,
+	graphqlClient graphql.Client,
+	artifactID string,
+) (manifest Manifest, rerr error) {
+	response, err := gql.ArtifactManifest(
+		ctx,
+		graphqlClient,
+		artifactID,
+	)
+	if err != nil {
+		return Manifest{}, err
+	} else if response == nil {
+		return Manifest{}, fmt.Errorf("could not get manifest for artifact")
+	}
+	artifact := response.Artifact
+	if artifact == nil {
+		return Manifest{}, fmt.Errorf("could not access artifact")
+	}
+	artifactManifest := artifact.CurrentManifest
+	if artifactManifest == nil {
+		return Manifest{}, fmt.Errorf("could not access manifest for artifact")
+	}
+	directURL := artifactManifest.GetFile().DirectUrl
+	manifest, err = loadManifestFromURL(directURL)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
 func loadManifestFromURL(url string) (Manifest, error) {
 	client := retryablehttp.NewClient()
 	client.Logger = observability.NewNoOpLogger()
@@ -48,6 +48,11 @@ type ArtifactSaveManager struct {
 	fileCache                    Cache
 	useArtifactProjectEntityInfo bool
 
+	// multipartChunkSize is the preferred part size for multipart uploads.
+	//
+	// If zero, S3DefaultChunkSize is used.
+	multipartChunkSize int64
+
 	// uploadsByName ensures that uploads for the same artifact name happen
 	// serially, so that version numbers are assigned deterministically.
 	uploadsByName *namedgoroutines.Operation[*ArtifactSaver]
@@ -58,6 +63,7 @@ func NewArtifactSaveManager(
 	graphqlClient graphql.Client,
 	fileTransferManager filetransfer.FileTransferManager,
 	useArtifactProjectEntityInfo bool,
+	multipartChunkSize int64,
 ) *ArtifactSaveManager {
 	workerPool := &errgroup.Group{}
 	workerPool.SetLimit(maxSimultaneousUploads)
@@ -68,6 +74,7 @@ func NewArtifactSaveManager(
 		fileTransferManager:          fileTransferManager,
 		fileCache:                    NewFileCache(UserCacheDir()),
 		useArtifactProjectEntityInfo: useArtifactProjectEntityInfo,
+		multipartChunkSize:           multipartChunkSize,
 		uploadsByName: namedgoroutines.New(
 			uploadBufferPerArtifactName,
 			workerPool,
@@ -115,6 +122,8 @@ func (as *ArtifactSaveManager) Save(
 			maxActiveBatches:             5,
 			resultChan:                   resultChan,
 			useArtifactProjectEntityInfo: as.useArtifactProjectEntityInfo,
+			multipartChunkSize:           as.multipartChunkSize,
+			multipartUploads:             make(map[string]*multipartUpload),
 		},
 	)
 
@@ -140,6 +149,11 @@ type ArtifactSaver struct {
 	numDone                      int
 	startTime                    time.Time
 	useArtifactProjectEntityInfo bool
+	multipartChunkSize           int64
+
+	// Progress of multipart uploads by file name, kept across retries.
+	multipartUploadsMu sync.Mutex
+	multipartUploads   map[string]*multipartUpload
 }
 
 type multipartUploadInfo = []gql.CreateArtifactFilesCreateArtifactFilesCreateArtifactFilesPayloadFilesFileConnectionEdgesFileEdgeNodeFileUploadMultipartUrlsUploadUrlPartsUploadUrlPart
@@ -299,10 +313,51 @@ func (as *ArtifactSaver) upsertManifest(
 	}
 }
 
+// existingFileDigests returns the files in a previous artifact version.
+//
+// The result maps file digests to the ID of the artifact in which the
+// file was first uploaded. It is empty if the manifest can't be fetched,
+// in which case all files are uploaded as usual.
+func (as *ArtifactSaver) existingFileDigests(baseArtifactID string) map[string]string {
+	baseManifest, err := fetchArtifactManifest(
+		as.ctx,
+		as.graphqlClient,
+		baseArtifactID,
+	)
+	if err != nil {
+		as.logger.Warn(
+			"artifacts: could not get previous version's manifest, uploading all files",
+			"baseArtifactID", baseArtifactID,
+			"error", err,
+		)
+		return nil
+	}
+
+	digests := make(map[string]string, len(baseManifest.Contents))
+	for _, entry := range baseManifest.Contents {
+		// References point to external data, not to uploaded files.
+		if entry.Ref != nil {
+			continue
+		}
+
+		if entry.BirthArtifactID != nil {
+			digests[entry.Digest] = *entry.BirthArtifactID
+		} else {
+			digests[entry.Digest] = baseArtifactID
+		}
+	}
+	return digests
+}
+
+// uploadFiles uploads all local files in the manifest.
+//
+// Files whose digests are keys in existingFiles are not uploaded. Instead,
+// their manifest entries point at the artifact that first uploaded them.
 func (as *ArtifactSaver) uploadFiles(
 	artifactID string,
 	manifest *Manifest,
 	manifestID string,
+	existingFiles map[string]string,
 ) error {
 	// Prepare GQL input for files that (might) need to be uploaded.
 	namedFileSpecs := map[string]gql.CreateArtifactFileSpecInput{}
@@ -310,16 +365,26 @@ func (as *ArtifactSaver) uploadFiles(
 		if entry.LocalPath == nil {
 			continue
 		}
-		parts, err := multiPartRequest(*entry.LocalPath)
+		if birthArtifactID, ok := existingFiles[entry.Digest]; ok {
+			entry.BirthArtifactID = &birthArtifactID
+			manifest.Contents[name] = entry
+			as.cacheEntry(entry)
+			continue
+		}
+		parts, err := multiPartRequest(*entry.LocalPath, as.multipartChunkSize)
 		if err != nil {
 			return err
 		}
+		if parts != nil {
+			entry.Parts = parts
+			manifest.Contents[name] = entry
+		}
 		fileSpec := gql.CreateArtifactFileSpecInput{
 			ArtifactID:         artifactID,
 			Name:               name,
 			Md5:                entry.Digest,
 			ArtifactManifestID: &manifestID,
-			UploadPartsInput:   parts,
+			UploadPartsInput:   uploadPartsInput(parts),
 		}
 		namedFileSpecs[name] = fileSpec
 	}
@@ -391,9 +456,8 @@ func (as *ArtifactSaver) processFiles(
 				continue
 			}
 			if fileInfo.multipartUploadInfo != nil {
-				partData := namedFileSpecs[fileInfo.name].UploadPartsInput
 				go func() {
-					doneChan <- as.uploadMultipart(*entry.LocalPath, fileInfo, partData)
+					doneChan <- as.uploadMultipart(*entry.LocalPath, fileInfo, entry.Parts)
 				}()
 			} else {
 				suboperation := wboperation.Get(as.ctx).Subtask(fileInfo.name)
@@ -494,10 +558,18 @@ const (
 	S3MinMultiUploadSize = 2 << 30   // 2 GiB, the threshold we've chosen to switch to multipart
 	S3MaxMultiUploadSize = 5 << 40   // 5 TiB, maximum possible object size
 	S3DefaultChunkSize   = 100 << 20 // 1 MiB
+	S3MinChunkSize       = 5 << 20   // 5 MiB, except for the last part
 	S3MaxParts           = 10000
+
+	// maxPartUploadAttempts is the number of times to try uploading
+	// the parts of a multipart upload before requesting new upload URLs.
+	maxPartUploadAttempts = 3
 )
 
-func multiPartRequest(path string) ([]gql.UploadPartsInput, error) {
+func multiPartRequest(
+	path string,
+	preferredChunkSize int64,
+) ([]ManifestEntryPart, error) {
 	fileInfo, err := os.Stat(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file size for path %s: %w", path, err)
@@ -512,6 +584,11 @@ func multiPartRequest(path string) ([]gql.UploadPartsInput, error) {
 		return nil, fmt.Errorf("file size exceeds maximum S3 object size: %v", fileSize)
 	}
 
+	return computeParts(path, getChunkSize(fileSize, preferredChunkSize))
+}
+
+// computeParts splits a file into chunks and computes their checksums.
+func computeParts(path string, chunkSize int64) ([]ManifestEntryPart, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -520,50 +597,220 @@ func multiPartRequest(path string) ([]gql.UploadPartsInput, error) {
 		_ = file.Close()
 	}()
 
-	partsInfo := []gql.UploadPartsInput{}
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []ManifestEntryPart{}
 	partNumber := int64(1)
-	buffer := make([]byte, getChunkSize(fileSize))
+	offset := int64(0)
+	buffer := make([]byte, chunkSize)
 	for {
-		bytesRead, err := file.Read(buffer)
-		if err != nil && err != io.EOF {
+		bytesRead, err := io.ReadFull(file, buffer)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 			return nil, err
 		}
 		if bytesRead == 0 {
 			break
 		}
-		partsInfo = append(partsInfo, gql.UploadPartsInput{
+		parts = append(parts, ManifestEntryPart{
 			PartNumber: partNumber,
+			Offset:     offset,
+			Size:       int64(bytesRead),
 			HexMD5:     hashencode.ComputeHexMD5(buffer[:bytesRead]),
 		})
 		partNumber++
+		offset += int64(bytesRead)
+	}
+
+	if offset != fileInfo.Size() {
+		return nil, fmt.Errorf(
+			"file %s changed while computing checksums", path)
+	}
+	return parts, nil
+}
+
+// uploadPartsInput returns the GraphQL input describing a file's parts.
+func uploadPartsInput(parts []ManifestEntryPart) []gql.UploadPartsInput {
+	if parts == nil {
+		return nil
+	}
+
+	input := make([]gql.UploadPartsInput, len(parts))
+	for i, part := range parts {
+		input[i] = gql.UploadPartsInput{
+			PartNumber: part.PartNumber,
+			HexMD5:     part.HexMD5,
+		}
+	}
+	return input
+}
+
+// multipartUpload records which parts of a multipart upload completed.
+//
+// It allows retrying a failed upload without re-sending parts that
+// already made it to the server.
+type multipartUpload struct {
+	// uploadID is the server's ID for the upload.
+	//
+	// Parts only count toward the upload with which they were sent.
+	uploadID string
+
+	// etags maps part numbers of completed parts to their ETags.
+	etags map[int64]string
+}
+
+// resumeMultipartUpload returns the progress of a file's upload.
+//
+// If an earlier attempt to upload the file with the same upload ID
+// completed some parts, those parts are kept. It is an error if the file
+// no longer matches the checksums of the parts that were sent.
+func (as *ArtifactSaver) resumeMultipartUpload(
+	path string,
+	fileInfo serverFileResponse,
+	parts []ManifestEntryPart,
+) (*multipartUpload, error) {
+	as.multipartUploadsMu.Lock()
+	defer as.multipartUploadsMu.Unlock()
+
+	upload := as.multipartUploads[fileInfo.name]
+	if upload == nil || upload.uploadID != fileInfo.uploadID {
+		upload = &multipartUpload{
+			uploadID: fileInfo.uploadID,
+			etags:    make(map[int64]string),
+		}
+		as.multipartUploads[fileInfo.name] = upload
+		return upload, nil
+	}
+
+	for _, part := range parts {
+		if _, ok := upload.etags[part.PartNumber]; !ok {
+			continue
+		}
+
+		if err := verifyPart(path, part); err != nil {
+			return nil, fmt.Errorf(
+				"cannot resume upload of %s: %v", fileInfo.name, err)
+		}
+	}
+
+	return upload, nil
+}
+
+// verifyPart checks that a part of a file matches its recorded checksum.
+func verifyPart(path string, part ManifestEntryPart) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	buffer := make([]byte, part.Size)
+	if _, err := file.ReadAt(buffer, part.Offset); err != nil {
+		return err
 	}
-	return partsInfo, nil
+
+	if hexMD5 := hashencode.ComputeHexMD5(buffer); hexMD5 != part.HexMD5 {
+		return fmt.Errorf(
+			"checksum mismatch for part %d: expected %s, got %s",
+			part.PartNumber, part.HexMD5, hexMD5,
+		)
+	}
+
+	return nil
 }
 
 func (as *ArtifactSaver) uploadMultipart(
 	path string,
 	fileInfo serverFileResponse,
-	partData []gql.UploadPartsInput,
+	parts []ManifestEntryPart,
 ) uploadResult {
-	statInfo, err := os.Stat(path)
+	if len(fileInfo.multipartUploadInfo) != len(parts) {
+		err := fmt.Errorf(
+			"expected %d part upload URLs, got %d",
+			len(parts), len(fileInfo.multipartUploadInfo),
+		)
+		return uploadResult{name: fileInfo.name, err: err}
+	}
+
+	upload, err := as.resumeMultipartUpload(path, fileInfo, parts)
 	if err != nil {
 		return uploadResult{name: fileInfo.name, err: err}
 	}
-	chunkSize := getChunkSize(statInfo.Size())
 
+	// Retry only the parts that failed, so that a transient error near the
+	// end of a large file doesn't restart the upload from the beginning.
+	for attempt := 1; ; attempt++ {
+		err := as.uploadIncompleteParts(path, fileInfo, parts, upload)
+		if err == nil {
+			break
+		}
+
+		if attempt >= maxPartUploadAttempts {
+			return uploadResult{name: fileInfo.name, err: err}
+		}
+
+		as.logger.Warn(
+			"artifacts: retrying incomplete parts",
+			"name", fileInfo.name,
+			"completed", len(upload.etags),
+			"total", len(parts),
+			"error", err,
+		)
+	}
+
+	partEtags := make([]gql.UploadPartsInput, len(parts))
+	for i, part := range parts {
+		partEtags[i] = gql.UploadPartsInput{
+			PartNumber: part.PartNumber,
+			HexMD5:     upload.etags[part.PartNumber],
+		}
+	}
+
+	_, err = gql.CompleteMultipartUploadArtifact(
+		as.ctx, as.graphqlClient, gql.CompleteMultipartActionComplete, partEtags,
+		fileInfo.birthArtifactID, *fileInfo.storagePath, fileInfo.uploadID,
+	)
+
+	if err == nil {
+		as.multipartUploadsMu.Lock()
+		delete(as.multipartUploads, fileInfo.name)
+		as.multipartUploadsMu.Unlock()
+	}
+
+	return uploadResult{name: fileInfo.name, err: err}
+}
+
+// uploadIncompleteParts uploads all parts that are not yet complete.
+//
+// Successfully uploaded parts are recorded in the upload even if
+// other parts fail. Returns the first error encountered, if any.
+func (as *ArtifactSaver) uploadIncompleteParts(
+	path string,
+	fileInfo serverFileResponse,
+	parts []ManifestEntryPart,
+	upload *multipartUpload,
+) error {
 	type partResponse struct {
 		partNumber int64
 		task       *filetransfer.DefaultUploadTask
 	}
 
 	wg := sync.WaitGroup{}
-	partResponses := make(chan partResponse, len(partData))
+	partResponses := make(chan partResponse, len(parts))
 	// TODO: add mid-upload cancel.
 
 	contentType := getContentType(fileInfo.uploadHeaders)
 
 	partInfo := fileInfo.multipartUploadInfo
-	for i, part := range partInfo {
+	for i, part := range parts {
+		if _, ok := upload.etags[part.PartNumber]; ok {
+			continue
+		}
+
 		suboperation := wboperation.Get(as.ctx).Subtask(
 			fmt.Sprintf(
 				"%s (%d/%d)",
@@ -571,13 +818,12 @@ func (as *ArtifactSaver) uploadMultipart(
 			))
 		task := newUploadTask(fileInfo, path)
 		task.Context = suboperation.Context(as.ctx)
-		task.Url = part.UploadUrl
-		task.Offset = int64(i) * chunkSize
-		remainingSize := statInfo.Size() - task.Offset
-		task.Size = min(remainingSize, chunkSize)
-		b64md5, err := hashencode.HexToB64(partData[i].HexMD5)
+		task.Url = partInfo[i].UploadUrl
+		task.Offset = part.Offset
+		task.Size = part.Size
+		b64md5, err := hashencode.HexToB64(part.HexMD5)
 		if err != nil {
-			return uploadResult{name: fileInfo.name, err: err}
+			return err
 		}
 		task.Headers = []string{
 			"Content-Md5:" + b64md5,
@@ -586,7 +832,7 @@ func (as *ArtifactSaver) uploadMultipart(
 		}
 		task.OnComplete = func() {
 			suboperation.Finish()
-			partResponses <- partResponse{partNumber: partData[i].PartNumber, task: task}
+			partResponses <- partResponse{partNumber: part.PartNumber, task: task}
 			wg.Done()
 		}
 		wg.Add(1)
@@ -598,45 +844,43 @@ func (as *ArtifactSaver) uploadMultipart(
 		close(partResponses)
 	}()
 
-	partEtags := make([]gql.UploadPartsInput, len(partData))
-
+	var firstErr error
 	for t := range partResponses {
-		err := t.task.Err
+		etag, err := partETag(t.partNumber, t.task, len(parts))
 		if err != nil {
-			return uploadResult{name: fileInfo.name, err: err}
-		}
-		if t.task.Response == nil {
-			err = fmt.Errorf("no response in task %v", t.task.Name)
-			return uploadResult{name: fileInfo.name, err: err}
-		}
-		etag := ""
-		if t.task.Response != nil {
-			etag = t.task.Response.Header.Get("ETag")
-			if etag == "" {
-				err = fmt.Errorf("no ETag in response %v", t.task.Response.Header)
-				return uploadResult{name: fileInfo.name, err: err}
+			if firstErr == nil {
+				firstErr = err
 			}
+			continue
 		}
-		// Part numbers should be unique values from 1 to len(partData).
-		if t.partNumber < 1 || t.partNumber > int64(len(partData)) {
-			err = fmt.Errorf("invalid part number: %d", t.partNumber)
-			return uploadResult{name: fileInfo.name, err: err}
-		}
-		if partEtags[t.partNumber-1].PartNumber != 0 {
-			err = fmt.Errorf("duplicate part number: %d", t.partNumber)
-			return uploadResult{name: fileInfo.name, err: err}
-		}
-		partEtags[t.partNumber-1] = gql.UploadPartsInput{
-			PartNumber: t.partNumber,
-			HexMD5:     etag,
-		}
+
+		upload.etags[t.partNumber] = etag
 	}
 
-	_, err = gql.CompleteMultipartUploadArtifact(
-		as.ctx, as.graphqlClient, gql.CompleteMultipartActionComplete, partEtags,
-		fileInfo.birthArtifactID, *fileInfo.storagePath, fileInfo.uploadID,
-	)
-	return uploadResult{name: fileInfo.name, err: err}
+	return firstErr
+}
+
+// partETag returns the ETag of an uploaded part.
+func partETag(
+	partNumber int64,
+	task *filetransfer.DefaultUploadTask,
+	numParts int,
+) (string, error) {
+	if task.Err != nil {
+		return "", task.Err
+	}
+	if task.Response == nil {
+		return "", fmt.Errorf("no response in task %v", task.Name)
+	}
+	etag := task.Response.Header.Get("ETag")
+	if etag == "" {
+		return "", fmt.Errorf("no ETag in response %v", task.Response.Header)
+	}
+	// Part numbers should be unique values from 1 to numParts.
+	if partNumber < 1 || partNumber > int64(numParts) {
+		return "", fmt.Errorf("invalid part number: %d", partNumber)
+	}
+	return etag, nil
 }
 
 func getContentType(headers []string) string {
@@ -648,9 +892,19 @@ func getContentType(headers []string) string {
 	return ""
 }
 
-func getChunkSize(fileSize int64) int64 {
-	if fileSize < S3DefaultChunkSize*S3MaxParts {
-		return S3DefaultChunkSize
+// getChunkSize returns the part size for a multipart upload.
+//
+// The preferred size is used if possible; if it is zero, the default is
+// used. The chunk size is increased if the file would otherwise need
+// more than S3MaxParts parts.
+func getChunkSize(fileSize int64, preferredChunkSize int64) int64 {
+	if preferredChunkSize <= 0 {
+		preferredChunkSize = S3DefaultChunkSize
+	}
+	preferredChunkSize = max(preferredChunkSize, S3MinChunkSize)
+
+	if fileSize <= preferredChunkSize*S3MaxParts {
+		return preferredChunkSize
 	}
 	// Use a larger chunk size if we would need more than 10,000 chunks.
 	chunkSize := int64(math.Ceil(float64(fileSize) / float64(S3MaxParts)))
@@ -808,7 +1062,12 @@ func (as *ArtifactSaver) Save() (artifactID string, rerr error) {
 		return "", fmt.Errorf("ArtifactSaver.createManifest: %w", err)
 	}
 
-	err = as.uploadFiles(artifactID, &manifest, manifestAttrs.Id)
+	var existingFiles map[string]string
+	if baseArtifactId != nil {
+		existingFiles = as.existingFileDigests(*baseArtifactId)
+	}
+
+	err = as.uploadFiles(artifactID, &manifest, manifestAttrs.Id, existingFiles)
 	if err != nil {
 		return "", fmt.Errorf("ArtifactSaver.uploadFiles: %w", err)
 	}
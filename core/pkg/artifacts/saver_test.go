@@ -2,11 +2,19 @@ package artifacts
 
 import (
 	"context"
+	"errors"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wandb/wandb/core/internal/filetransfer"
 	"github.com/wandb/wandb/core/internal/filetransfertest"
 	"github.com/wandb/wandb/core/internal/gqlmock"
 	"github.com/wandb/wandb/core/internal/observability"
@@ -30,7 +38,7 @@ func TestGetChunkSize(t *testing.T) {
 	}
 
 	for _, fileSize := range fileSizes {
-		chunkSize := getChunkSize(fileSize)
+		chunkSize := getChunkSize(fileSize, 0)
 		assert.GreaterOrEqual(t, chunkSize, defaultChunkSize)
 		// Chunk size should always be a multiple of 4096.
 		assert.True(t, chunkSize%4096 == 0)
@@ -84,6 +92,7 @@ func TestSaveGraphQLRequest(t *testing.T) {
 		mockGQL,
 		ftm,
 		true,
+		0,
 	)
 
 	result := <-saver.Save(
@@ -106,3 +115,278 @@ func TestSaveGraphQLRequest(t *testing.T) {
 		createArtifactRequest,
 		gqlmock.GQLVar("input.entityName", gomock.Eq("test-entity")))
 }
+
+func TestGetChunkSize_Preferred(t *testing.T) {
+	assert.EqualValues(t, 8<<20, getChunkSize(1<<30, 8<<20))
+	assert.EqualValues(t, S3MinChunkSize, getChunkSize(1<<30, 1))
+}
+
+func TestComputeParts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte("aaaabbbbcc"), 0644))
+
+	parts, err := computeParts(path, 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, []ManifestEntryPart{
+		{PartNumber: 1, Offset: 0, Size: 4, HexMD5: "74b87337454200d4d33f80c4663dc5e5"},
+		{PartNumber: 2, Offset: 4, Size: 4, HexMD5: "65ba841e01d6db7733e90a5b7f9e6f80"},
+		{PartNumber: 3, Offset: 8, Size: 2, HexMD5: "e0323a9039add2978bf5b49550572c7c"},
+	}, parts)
+}
+
+// partFailingFileTransferManager completes upload tasks immediately,
+// failing the ones for which shouldFail returns true.
+type partFailingFileTransferManager struct {
+	mu         sync.Mutex
+	attempts   map[string]int
+	shouldFail func(url string, attempt int) bool
+}
+
+func (m *partFailingFileTransferManager) AddTask(t filetransfer.Task) {
+	task := t.(*filetransfer.DefaultUploadTask)
+
+	m.mu.Lock()
+	m.attempts[task.Url]++
+	attempt := m.attempts[task.Url]
+	m.mu.Unlock()
+
+	if m.shouldFail(task.Url, attempt) {
+		task.Err = errors.New("upload failed")
+	} else {
+		task.Response = &http.Response{
+			Header: http.Header{"Etag": []string{"etag-" + task.Url}},
+		}
+	}
+	task.Complete(nil)
+}
+
+func (m *partFailingFileTransferManager) Close() {}
+
+func setupMultipartUpload(
+	t *testing.T,
+	shouldFail func(url string, attempt int) bool,
+) (
+	*ArtifactSaver,
+	*partFailingFileTransferManager,
+	*gqlmock.MockClient,
+	string,
+	serverFileResponse,
+	[]ManifestEntryPart,
+) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte("aaaabbbbcc"), 0644))
+	parts, err := computeParts(path, 4)
+	require.NoError(t, err)
+
+	ftm := &partFailingFileTransferManager{
+		attempts:   make(map[string]int),
+		shouldFail: shouldFail,
+	}
+	mockGQL := gqlmock.NewMockClient()
+	saver := &ArtifactSaver{
+		ctx:                 context.Background(),
+		logger:              observability.NewNoOpLogger(),
+		graphqlClient:       mockGQL,
+		fileTransferManager: ftm,
+		multipartUploads:    make(map[string]*multipartUpload),
+	}
+
+	storagePath := "storage-path"
+	uploadURL := "upload-url"
+	fileInfo := serverFileResponse{
+		name:            "file",
+		uploadUrl:       &uploadURL,
+		birthArtifactID: "artifact-id",
+		uploadID:        "upload-id",
+		storagePath:     &storagePath,
+		multipartUploadInfo: multipartUploadInfo{
+			{PartNumber: 1, UploadUrl: "url-1"},
+			{PartNumber: 2, UploadUrl: "url-2"},
+			{PartNumber: 3, UploadUrl: "url-3"},
+		},
+	}
+
+	return saver, ftm, mockGQL, path, fileInfo, parts
+}
+
+func TestUploadMultipart_RetriesOnlyFailedParts(t *testing.T) {
+	saver, ftm, mockGQL, path, fileInfo, parts := setupMultipartUpload(t,
+		func(url string, attempt int) bool {
+			return url == "url-2" && attempt == 1
+		})
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CompleteMultipartUploadArtifact"),
+		`{"completeMultipartUploadArtifact": {"digest": "digest"}}`,
+	)
+
+	result := saver.uploadMultipart(path, fileInfo, parts)
+
+	require.NoError(t, result.err)
+	assert.Equal(t,
+		map[string]int{"url-1": 1, "url-2": 2, "url-3": 1},
+		ftm.attempts)
+	gqlmock.AssertVariables(t,
+		mockGQL.AllRequests()[0],
+		gqlmock.GQLVar("completedParts", gomock.Eq([]any{
+			map[string]any{"partNumber": float64(1), "hexMD5": "etag-url-1"},
+			map[string]any{"partNumber": float64(2), "hexMD5": "etag-url-2"},
+			map[string]any{"partNumber": float64(3), "hexMD5": "etag-url-3"},
+		})))
+}
+
+func TestUploadMultipart_ResumesFromIncompleteParts(t *testing.T) {
+	failPart2 := true
+	saver, ftm, mockGQL, path, fileInfo, parts := setupMultipartUpload(t,
+		func(url string, attempt int) bool {
+			return url == "url-2" && failPart2
+		})
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CompleteMultipartUploadArtifact"),
+		`{"completeMultipartUploadArtifact": {"digest": "digest"}}`,
+	)
+
+	result := saver.uploadMultipart(path, fileInfo, parts)
+	require.Error(t, result.err)
+	assert.Equal(t,
+		map[string]int{"url-1": 1, "url-2": maxPartUploadAttempts, "url-3": 1},
+		ftm.attempts)
+
+	failPart2 = false
+	result = saver.uploadMultipart(path, fileInfo, parts)
+	require.NoError(t, result.err)
+	assert.Equal(t,
+		map[string]int{"url-1": 1, "url-2": maxPartUploadAttempts + 1, "url-3": 1},
+		ftm.attempts)
+}
+
+func TestUploadMultipart_FailsToResumeIfFileChanged(t *testing.T) {
+	saver, ftm, _, path, fileInfo, parts := setupMultipartUpload(t,
+		func(url string, attempt int) bool { return url == "url-2" })
+	_ = saver.uploadMultipart(path, fileInfo, parts)
+
+	// Change the data of a part that was already uploaded.
+	require.NoError(t, os.WriteFile(path, []byte("xxxxbbbbcc"), 0644))
+	result := saver.uploadMultipart(path, fileInfo, parts)
+
+	assert.ErrorContains(t, result.err, "checksum mismatch for part 1")
+	assert.Equal(t, 1, ftm.attempts["url-1"])
+}
+
+func TestSave_SkipsFilesInPreviousVersion(t *testing.T) {
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "a.txt")
+	pathB := filepath.Join(dir, "b.txt")
+	require.NoError(t, os.WriteFile(pathA, []byte("unchanged"), 0644))
+	require.NoError(t, os.WriteFile(pathB, []byte("changed"), 0644))
+	baseManifestServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{
+				"version": 1,
+				"contents": {
+					"a.txt": {"digest": "digest-a", "birthArtifactID": "first-id"},
+					"b.txt": {"digest": "digest-b-old"}
+				}
+			}`))
+		}))
+	defer baseManifestServer.Close()
+
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("InputFields"),
+		`{"TypeInfo": {"inputFields": []}}`,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CreateArtifact"),
+		`{
+			"createArtifact": {
+				"artifact": {
+					"id": "artifact-id",
+					"state": "PENDING",
+					"artifactSequence": {"latestArtifact": {"id": "base-id"}}
+				}
+			}
+		}`,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CreateArtifactManifest"),
+		`{"createArtifactManifest": {}}`,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("ArtifactManifest"),
+		`{
+			"artifact": {
+				"currentManifest": {
+					"file": {"directUrl": "`+baseManifestServer.URL+`"}
+				}
+			}
+		}`,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CreateArtifactFiles"),
+		`{
+			"createArtifactFiles": {
+				"files": {
+					"edges": [{
+						"node": {
+							"uploadUrl": "b-upload-url",
+							"artifact": {"id": "artifact-id"}
+						}
+					}]
+				}
+			}
+		}`,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CreateArtifactManifest"),
+		`{
+			"createArtifactManifest": {
+				"artifactManifest": {
+					"file": {
+						"uploadUrl": "test-url"
+					}
+				}
+			}
+		}`,
+	)
+	ftm := filetransfertest.NewFakeFileTransferManager()
+	ftm.ShouldCompleteImmediately = true
+	saver := NewArtifactSaveManager(
+		observability.NewNoOpLogger(),
+		mockGQL,
+		ftm,
+		true,
+		0,
+	)
+
+	result := <-saver.Save(
+		context.Background(),
+		&spb.ArtifactRecord{
+			Manifest: &spb.ArtifactManifest{
+				Version: 1,
+				Contents: []*spb.ArtifactManifestEntry{
+					{Path: "a.txt", Digest: "digest-a", LocalPath: pathA, SkipCache: true},
+					{Path: "b.txt", Digest: "digest-b-new", LocalPath: pathB, SkipCache: true},
+				},
+			},
+		},
+		0,
+		"",
+	)
+
+	require.NoError(t, result.Err)
+	assert.True(t, mockGQL.AllStubsUsed())
+	var uploadedURLs []string
+	for _, task := range ftm.Tasks() {
+		uploadedURLs = append(uploadedURLs, task.(*filetransfer.DefaultUploadTask).Url)
+	}
+	assert.Equal(t, []string{"b-upload-url", "test-url"}, uploadedURLs)
+	for _, req := range mockGQL.AllRequests() {
+		if req.OpName == "CreateArtifactFiles" {
+			gqlmock.AssertVariables(t, req,
+				gqlmock.GQLVar("artifactFiles", gomock.Len(1)))
+		}
+	}
+}